@@ -0,0 +1,58 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+// callStackTracker remembers when pending call stacks of a brick were seen first. The callpool
+// output of gluster carries no timestamps, so the age of a call stack is measured from the first
+// scrape it showed up in.
+type callStackTracker struct {
+	mu        sync.Mutex
+	firstSeen map[string]map[string]time.Time
+}
+
+func newCallStackTracker() *callStackTracker {
+	return &callStackTracker{firstSeen: make(map[string]map[string]time.Time)}
+}
+
+// observe records the pending call stacks of a brick and returns the age of the oldest one.
+// Call stacks which are not pending anymore are forgotten.
+func (t *callStackTracker) observe(brick string, uniques []string, now time.Time) time.Duration {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	previous := t.firstSeen[brick]
+	current := make(map[string]time.Time, len(uniques))
+	var oldest time.Duration
+	for _, unique := range uniques {
+		seen, ok := previous[unique]
+		if !ok {
+			seen = now
+		}
+		current[unique] = seen
+		if age := now.Sub(seen); age > oldest {
+			oldest = age
+		}
+	}
+
+	if len(current) > 0 {
+		t.firstSeen[brick] = current
+	} else {
+		delete(t.firstSeen, brick)
+	}
+	return oldest
+}
+
+// prune forgets the call stacks of bricks which weren't observed in the latest scrape, e.g. because the brick
+// was removed or its volume deleted
+func (t *callStackTracker) prune(observed map[string]bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for brick := range t.firstSeen {
+		if !observed[brick] {
+			delete(t.firstSeen, brick)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCallStackTrackerObserve(t *testing.T) {
+	tracker := newCallStackTracker()
+	start := time.Unix(1494400000, 0)
+	brick := "node1.example.local:/mnt/gluster/gv_test"
+
+	if age := tracker.observe(brick, []string{"18301"}, start); age != 0 {
+		t.Errorf("Expected age 0 for new call stack, got %v", age)
+	}
+
+	if age := tracker.observe(brick, []string{"18301", "18342"}, start.Add(30*time.Second)); age != 30*time.Second {
+		t.Errorf("Expected age 30s for pending call stack, got %v", age)
+	}
+
+	if age := tracker.observe(brick, []string{"18342"}, start.Add(60*time.Second)); age != 30*time.Second {
+		t.Errorf("Expected age 30s after oldest call stack completed, got %v", age)
+	}
+
+	if age := tracker.observe(brick, nil, start.Add(90*time.Second)); age != 0 {
+		t.Errorf("Expected age 0 without pending call stacks, got %v", age)
+	}
+
+	if len(tracker.firstSeen) != 0 {
+		t.Errorf("Expected brick to be forgotten, got %v", tracker.firstSeen)
+	}
+
+	if age := tracker.observe(brick, []string{"18342"}, start.Add(120*time.Second)); age != 0 {
+		t.Errorf("Expected age 0 for call stack seen again, got %v", age)
+	}
+}
+
+func TestCallStackTrackerPrune(t *testing.T) {
+	tracker := newCallStackTracker()
+	now := time.Unix(1494400000, 0)
+	removed := "node1.example.local:/mnt/gluster/gv_removed"
+	brick := "node1.example.local:/mnt/gluster/gv_test"
+
+	tracker.observe(removed, []string{"18301"}, now)
+	tracker.observe(brick, []string{"18342"}, now)
+	tracker.prune(map[string]bool{brick: true})
+
+	if _, ok := tracker.firstSeen[removed]; ok {
+		t.Error("Expected brick which wasn't observed to be forgotten")
+	}
+	if age := tracker.observe(brick, []string{"18342"}, now.Add(30*time.Second)); age != 30*time.Second {
+		t.Errorf("Expected age 30s for observed brick, got %v", age)
+	}
+}
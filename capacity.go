@@ -0,0 +1,206 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+// brickSize is the size of the file system of a brick as reported by "gluster volume status all detail"
+type brickSize struct {
+	total uint64
+	free  uint64
+}
+
+// brickDevice identifies the file system of a brick in the cluster
+type brickDevice struct {
+	hostname string
+	device   string
+}
+
+// brickFilesystem is the file system of a brick and its size
+type brickFilesystem struct {
+	brickDevice
+	brickSize
+}
+
+// brickSizes returns the file systems of all online bricks of "gluster volume status all detail" keyed by
+// "hostname:path", the name of the brick in "gluster volume info". Offline bricks don't report sizes.
+func brickSizes(volumeStatus structs.VolumeStatusXML) map[string]brickFilesystem {
+	sizes := make(map[string]brickFilesystem)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Status != 1 || node.SizeTotal == 0 {
+				continue
+			}
+			sizes[node.Hostname+":"+node.Path] = brickFilesystem{
+				brickDevice: brickDevice{hostname: node.Hostname, device: node.Device},
+				brickSize:   brickSize{total: node.SizeTotal, free: node.SizeFree},
+			}
+		}
+	}
+	return sizes
+}
+
+// deviceSizes returns the sizes of the file systems of online bricks and the number of bricks on each. Bricks sharing
+// a file system each report its full size, so summing the sizes of bricks counts the file system once per brick.
+func deviceSizes(volumeStatus structs.VolumeStatusXML) (map[brickDevice]brickSize, map[brickDevice]int) {
+	sizes := make(map[brickDevice]brickSize)
+	bricks := make(map[brickDevice]int)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Status != 1 || node.SizeTotal == 0 || len(node.Device) == 0 {
+				continue
+			}
+			device := brickDevice{hostname: node.Hostname, device: node.Device}
+			sizes[device] = brickSize{total: node.SizeTotal, free: node.SizeFree}
+			bricks[device]++
+		}
+	}
+	return sizes, bricks
+}
+
+// subvolumes splits the bricks of a volume into its replica or disperse sets, which DHT distributes files to.
+// The bricks of "gluster volume info" are listed set by set. Each brick of a distribute volume is a subvolume.
+func subvolumes(volume structs.Volume) [][]structs.Brick {
+	size := 1
+	if volume.DisperseCount > 0 {
+		size = volume.DisperseCount
+	} else if volume.ReplicaCount > 1 {
+		size = volume.ReplicaCount
+	}
+	bricks := volume.Bricks.Brick
+	var sets [][]structs.Brick
+	for len(bricks) > 0 {
+		n := size
+		if n > len(bricks) {
+			n = len(bricks)
+		}
+		sets = append(sets, bricks[:n])
+		bricks = bricks[n:]
+	}
+	return sets
+}
+
+// subvolumeCapacity returns the bytes clients can store in a subvolume. A replica set can't hold more than its
+// smallest data brick, arbiter bricks only store metadata. A disperse set stores data on disperse - redundancy
+// bricks, each brick holding an equal fragment. Bricks without a size are ignored, false is returned if no brick
+// of the subvolume has one.
+func subvolumeCapacity(volume structs.Volume, bricks []structs.Brick, sizes map[string]brickFilesystem) (brickSize, bool) {
+	var capacity brickSize
+	found := false
+	for _, brick := range bricks {
+		if brick.IsArbiter == 1 {
+			continue
+		}
+		fs, ok := sizes[brick.Name]
+		if !ok {
+			continue
+		}
+		size := fs.brickSize
+		if !found || size.total < capacity.total {
+			capacity.total = size.total
+		}
+		if !found || size.free < capacity.free {
+			capacity.free = size.free
+		}
+		found = true
+	}
+	if volume.DisperseCount > 0 {
+		fragments := uint64(volume.DisperseCount - volume.RedundancyCount)
+		capacity.total *= fragments
+		capacity.free *= fragments
+	}
+	return capacity, found
+}
+
+// subvolumeUsage is the capacity of a replica or disperse set, named like its xlator in the client volfile
+type subvolumeUsage struct {
+	name string
+	brickSize
+}
+
+// subvolumeName returns the name of the i-th subvolume of a volume, "VOLNAME-replicate-N", "VOLNAME-disperse-N"
+// or "VOLNAME-client-N" for the bricks of a distribute volume
+func subvolumeName(volume structs.Volume, i int) string {
+	kind := "client"
+	if volume.DisperseCount > 0 {
+		kind = "disperse"
+	} else if volume.ReplicaCount > 1 {
+		kind = "replicate"
+	}
+	return fmt.Sprintf("%s-%s-%d", volume.Name, kind, i)
+}
+
+// subvolumeUsages returns the capacity of the subvolumes of a volume which have a brick with a size
+func subvolumeUsages(volume structs.Volume, sizes map[string]brickFilesystem) []subvolumeUsage {
+	var usages []subvolumeUsage
+	for i, bricks := range subvolumes(volume) {
+		size, ok := subvolumeCapacity(volume, bricks, sizes)
+		if !ok {
+			continue
+		}
+		usages = append(usages, subvolumeUsage{
+			name:      subvolumeName(volume, i),
+			brickSize: size,
+		})
+	}
+	return usages
+}
+
+// volumeCapacity is the usable capacity of a volume summed over its subvolumes
+type volumeCapacity struct {
+	total uint64
+	free  uint64
+	// minSubvolumeFree is the free space of the fullest subvolume, DHT places files on a single subvolume
+	// so writes fail on it first
+	minSubvolumeFree uint64
+}
+
+// sharedSizes returns the sizes of the bricks of a volume split evenly between the data bricks of the volume on the
+// same file system. Each brick reports the full size of its file system, adding up the bricks on one file system
+// would count it several times, whether their subvolumes share all or only some file systems. Bricks without a
+// device are assumed to have a file system of their own.
+func sharedSizes(volume structs.Volume, sizes map[string]brickFilesystem) map[string]brickFilesystem {
+	bricks := make(map[brickDevice]uint64)
+	for _, brick := range volume.Bricks.Brick {
+		if fs, ok := sizes[brick.Name]; ok && brick.IsArbiter != 1 && len(fs.device) > 0 {
+			bricks[fs.brickDevice]++
+		}
+	}
+	shared := make(map[string]brickFilesystem, len(volume.Bricks.Brick))
+	for _, brick := range volume.Bricks.Brick {
+		fs, ok := sizes[brick.Name]
+		if !ok {
+			continue
+		}
+		if n := bricks[fs.brickDevice]; n > 1 && len(fs.device) > 0 {
+			fs.total /= n
+			fs.free /= n
+		}
+		shared[brick.Name] = fs
+	}
+	return shared
+}
+
+// usableCapacity returns the usable capacity of a volume. The subvolumes are added up with the share of the file
+// systems of their bricks, see sharedSizes. File systems shared with bricks of other volumes are counted by each
+// volume. False is returned if no brick of the volume has a size.
+func usableCapacity(volume structs.Volume, sizes map[string]brickFilesystem) (volumeCapacity, bool) {
+	var capacity volumeCapacity
+	shared := sharedSizes(volume, sizes)
+	for _, bricks := range subvolumes(volume) {
+		if size, ok := subvolumeCapacity(volume, bricks, shared); ok {
+			capacity.total += size.total
+			capacity.free += size.free
+		}
+	}
+	// DHT compares the free space each brick reports, not its share
+	usages := subvolumeUsages(volume, sizes)
+	for i, usage := range usages {
+		if i == 0 || usage.free < capacity.minSubvolumeFree {
+			capacity.minSubvolumeFree = usage.free
+		}
+	}
+	return capacity, len(usages) > 0
+}
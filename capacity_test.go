@@ -0,0 +1,138 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+const gib = 1 << 30
+
+func readLayoutFixtures(t *testing.T) (structs.VolumeInfoXML, structs.VolumeStatusXML) {
+	file, err := os.Open("test/gluster_volume_info_layouts.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	volumeInfo, err := structs.VolumeInfoXMLUnmarshall(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	file, err = os.Open("test/gluster_volume_status_all_detail_layouts.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	volumeStatus, err := structs.VolumeStatusAllDetailXMLUnmarshall(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return volumeInfo, volumeStatus
+}
+
+func TestSubvolumes(t *testing.T) {
+	volumeInfo, _ := readLayoutFixtures(t)
+	want := map[string][]int{
+		"gv_arbiter":  {3, 3},
+		"gv_disperse": {6},
+		"gv_dist":     {1, 1, 1},
+	}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		var got []int
+		for _, bricks := range subvolumes(volume) {
+			got = append(got, len(bricks))
+		}
+		if !reflect.DeepEqual(got, want[volume.Name]) {
+			t.Errorf("%v: want subvolumes of %v bricks, got %v", volume.Name, want[volume.Name], got)
+		}
+	}
+}
+
+func TestUsableCapacity(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	sizes := brickSizes(volumeStatus)
+	if _, ok := sizes["node3.example.local:/bricks/gv_disperse/b2"]; ok {
+		t.Error("Expected no size for offline brick")
+	}
+
+	want := map[string]volumeCapacity{
+		// the arbiter bricks don't hold data, the smallest data brick limits each replica set
+		"gv_arbiter": {total: 200 * gib, free: 48 * gib, minSubvolumeFree: 10 * gib},
+		// 4 data fragments of the fullest brick, the offline brick is ignored. Two bricks of node1 share a file
+		// system, each holding a fragment of every file, so each brick only has half of it.
+		"gv_disperse": {total: 100 * gib, free: 40 * gib, minSubvolumeFree: 80 * gib},
+		"gv_dist":     {total: 30 * gib, free: 15 * gib, minSubvolumeFree: 1 * gib},
+	}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		capacity, ok := usableCapacity(volume, sizes)
+		if !ok || capacity != want[volume.Name] {
+			t.Errorf("%v: want %+v, got %+v (%v)", volume.Name, want[volume.Name], capacity, ok)
+		}
+	}
+
+	if _, ok := usableCapacity(volumeInfo.VolInfo.Volumes.Volume[0], nil); ok {
+		t.Error("Expected no capacity without brick sizes")
+	}
+}
+
+func TestDeviceSizes(t *testing.T) {
+	_, volumeStatus := readLayoutFixtures(t)
+	sizes, bricks := deviceSizes(volumeStatus)
+
+	shared := brickDevice{hostname: "node1.example.local", device: "/dev/mapper/gluster-gv_disperse"}
+	if want := (brickSize{total: 50 * gib, free: 20 * gib}); sizes[shared] != want || bricks[shared] != 2 {
+		t.Errorf("want %+v on 2 bricks, got %+v on %v bricks", want, sizes[shared], bricks[shared])
+	}
+	// the offline brick doesn't report its file system
+	if want := 13; len(sizes) != want || len(bricks) != want {
+		t.Errorf("want %v devices, got %v", want, len(sizes))
+	}
+}
+
+func TestUsableCapacitySharedFilesystem(t *testing.T) {
+	volume := structs.Volume{
+		Name:         "gv_shared",
+		ReplicaCount: 1,
+		Bricks: structs.VolumeBricks{Brick: []structs.Brick{
+			{Name: "node1:/bricks/gv_shared/b1"},
+			{Name: "node1:/bricks/gv_shared/b2"},
+			{Name: "node2:/bricks/gv_shared/b1"},
+		}},
+	}
+	sizes := map[string]brickFilesystem{
+		"node1:/bricks/gv_shared/b1": {brickDevice{"node1", "/dev/sdb"}, brickSize{total: 10 * gib, free: 4 * gib}},
+		"node1:/bricks/gv_shared/b2": {brickDevice{"node1", "/dev/sdb"}, brickSize{total: 10 * gib, free: 4 * gib}},
+		"node2:/bricks/gv_shared/b1": {brickDevice{"node2", "/dev/sdb"}, brickSize{total: 10 * gib, free: 6 * gib}},
+	}
+	want := volumeCapacity{total: 20 * gib, free: 10 * gib, minSubvolumeFree: 4 * gib}
+	if capacity, ok := usableCapacity(volume, sizes); !ok || capacity != want {
+		t.Errorf("want %+v, got %+v (%v)", want, capacity, ok)
+	}
+}
+
+func TestUsableCapacityPartiallySharedFilesystem(t *testing.T) {
+	// both replica sets have a brick on /dev/sdb of node1, the second brick of each set has a file system of its own
+	volume := structs.Volume{
+		Name:         "gv_partial",
+		ReplicaCount: 2,
+		Bricks: structs.VolumeBricks{Brick: []structs.Brick{
+			{Name: "node1:/bricks/gv_partial/b1"},
+			{Name: "node2:/bricks/gv_partial/b1"},
+			{Name: "node1:/bricks/gv_partial/b2"},
+			{Name: "node3:/bricks/gv_partial/b1"},
+		}},
+	}
+	sizes := map[string]brickFilesystem{
+		"node1:/bricks/gv_partial/b1": {brickDevice{"node1", "/dev/sdb"}, brickSize{total: 20 * gib, free: 10 * gib}},
+		"node1:/bricks/gv_partial/b2": {brickDevice{"node1", "/dev/sdb"}, brickSize{total: 20 * gib, free: 10 * gib}},
+		"node2:/bricks/gv_partial/b1": {brickDevice{"node2", "/dev/sdb"}, brickSize{total: 20 * gib, free: 10 * gib}},
+		"node3:/bricks/gv_partial/b1": {brickDevice{"node3", "/dev/sdb"}, brickSize{total: 20 * gib, free: 10 * gib}},
+	}
+	want := volumeCapacity{total: 20 * gib, free: 10 * gib, minSubvolumeFree: 10 * gib}
+	if capacity, ok := usableCapacity(volume, sizes); !ok || capacity != want {
+		t.Errorf("want %+v, got %+v (%v)", want, capacity, ok)
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+// clientHost sums the connections of a client host to a brick
+type clientHost struct {
+	host        string
+	connections int
+	bytesRead   uint64
+	bytesWrite  uint64
+	// opVersion is the lowest op-version of the connections
+	opVersion int
+}
+
+// clientHostname strips the port from the address of a client connection. The port is ephemeral and changes
+// with every reconnect.
+func clientHostname(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return address
+	}
+	return host
+}
+
+// aggregateClients sums the client connections of a brick per client host, in the order the hosts first appear
+func aggregateClients(clients []structs.BrickClient) []clientHost {
+	var hosts []clientHost
+	index := make(map[string]int)
+	for _, client := range clients {
+		host := clientHostname(client.Hostname)
+		i, ok := index[host]
+		if !ok {
+			index[host] = len(hosts)
+			hosts = append(hosts, clientHost{host: host, opVersion: client.OpVersion})
+			i = len(hosts) - 1
+		}
+		sum := &hosts[i]
+		sum.connections++
+		sum.bytesRead += client.BytesRead
+		sum.bytesWrite += client.BytesWrite
+		if client.OpVersion < sum.opVersion {
+			sum.opVersion = client.OpVersion
+		}
+	}
+	return hosts
+}
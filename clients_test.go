@@ -0,0 +1,40 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+func TestClientHostname(t *testing.T) {
+	tests := []struct {
+		address string
+		want    string
+	}{
+		{"10.0.1.111:1023", "10.0.1.111"},
+		{"[fd00::11]:49151", "fd00::11"},
+		{"node1.example.local:1021", "node1.example.local"},
+		{"10.0.1.111", "10.0.1.111"},
+	}
+	for _, c := range tests {
+		if got := clientHostname(c.address); got != c.want {
+			t.Errorf("clientHostname(%q) == %q, want %q", c.address, got, c.want)
+		}
+	}
+}
+
+func TestAggregateClients(t *testing.T) {
+	clients := []structs.BrickClient{
+		{Hostname: "10.0.1.111:1023", BytesRead: 100, BytesWrite: 1000, OpVersion: 31202},
+		{Hostname: "10.0.1.20:1019", BytesRead: 5, BytesWrite: 50, OpVersion: 30712},
+		{Hostname: "10.0.1.111:1017", BytesRead: 20, BytesWrite: 200, OpVersion: 30712},
+	}
+	want := []clientHost{
+		{host: "10.0.1.111", connections: 2, bytesRead: 120, bytesWrite: 1200, opVersion: 30712},
+		{host: "10.0.1.20", connections: 1, bytesRead: 5, bytesWrite: 50, opVersion: 30712},
+	}
+	if got := aggregateClients(clients); !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %+v, got: %+v", want, got)
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+const (
+	// minFreeDiskOption is the option reserving free space on each subvolume, DHT stops placing new files on
+	// subvolumes below it
+	minFreeDiskOption = "cluster.min-free-disk"
+
+	// DefaultMinFreeDisk is the default value of cluster.min-free-disk
+	DefaultMinFreeDisk = "10%"
+)
+
+// fillSkew is the spread of the used ratio of the subvolumes of a volume
+type fillSkew struct {
+	min    float64
+	max    float64
+	stddev float64
+}
+
+// subvolumeFillSkew returns the minimum, maximum and standard deviation of the used ratio of subvolumes.
+// Subvolumes without a valid size are ignored, false is returned if none is left.
+func subvolumeFillSkew(usages []subvolumeUsage) (fillSkew, bool) {
+	var ratios []float64
+	for _, usage := range usages {
+		if usage.total == 0 || usage.free > usage.total {
+			continue
+		}
+		ratios = append(ratios, float64(usage.total-usage.free)/float64(usage.total))
+	}
+	if len(ratios) == 0 {
+		return fillSkew{}, false
+	}
+
+	skew := fillSkew{min: ratios[0], max: ratios[0]}
+	sum := 0.0
+	for _, ratio := range ratios {
+		skew.min = math.Min(skew.min, ratio)
+		skew.max = math.Max(skew.max, ratio)
+		sum += ratio
+	}
+	mean := sum / float64(len(ratios))
+	variance := 0.0
+	for _, ratio := range ratios {
+		variance += (ratio - mean) * (ratio - mean)
+	}
+	skew.stddev = math.Sqrt(variance / float64(len(ratios)))
+	return skew, true
+}
+
+// volumeOption returns the value of an option set on a volume
+func volumeOption(volume structs.Volume, name string) (string, bool) {
+	for _, option := range volume.Options.Option {
+		if option.Name == name {
+			return option.Value, true
+		}
+	}
+	return "", false
+}
+
+// minFreeDisk is a parsed value of cluster.min-free-disk, either a percentage of the subvolume size or bytes
+type minFreeDisk struct {
+	percent float64
+	bytes   uint64
+}
+
+// parseMinFreeDisk parses a value of cluster.min-free-disk like "10%", "500MB" or "1073741824"
+func parseMinFreeDisk(value string) (minFreeDisk, error) {
+	value = strings.TrimSpace(value)
+	if strings.HasSuffix(value, "%") {
+		percent, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percent < 0 || percent > 100 {
+			return minFreeDisk{}, fmt.Errorf("invalid percentage %q of %v", value, minFreeDiskOption)
+		}
+		return minFreeDisk{percent: percent}, nil
+	}
+
+	units := []string{"KB", "MB", "GB", "TB", "PB"}
+	number, multiplier := strings.ToUpper(value), uint64(1)
+	for i, unit := range units {
+		if strings.HasSuffix(number, unit) {
+			number = strings.TrimSuffix(number, unit)
+			multiplier = 1 << (10 * uint(i+1))
+			break
+		}
+	}
+	bytes, err := strconv.ParseUint(strings.TrimSuffix(strings.TrimSpace(number), "B"), 10, 64)
+	if err != nil {
+		return minFreeDisk{}, fmt.Errorf("invalid size %q of %v", value, minFreeDiskOption)
+	}
+	return minFreeDisk{bytes: bytes * multiplier}, nil
+}
+
+// reserved returns the bytes of a subvolume of size total reserved by cluster.min-free-disk
+func (m minFreeDisk) reserved(total uint64) float64 {
+	if m.bytes > 0 {
+		return float64(m.bytes)
+	}
+	return float64(total) * m.percent / 100
+}
+
+// volumeMinFreeDisk returns cluster.min-free-disk of a volume, or its default if the option isn't set
+func volumeMinFreeDisk(volume structs.Volume) (minFreeDisk, error) {
+	value, ok := volumeOption(volume, minFreeDiskOption)
+	if !ok {
+		value = DefaultMinFreeDisk
+	}
+	return parseMinFreeDisk(value)
+}
+
+// closestToMinFreeDisk returns the subvolume with the least free bytes left above cluster.min-free-disk and
+// these bytes, which are negative once the subvolume fell below it
+func closestToMinFreeDisk(usages []subvolumeUsage, threshold minFreeDisk) (string, float64, bool) {
+	name, headroom := "", 0.0
+	for i, usage := range usages {
+		left := float64(usage.free) - threshold.reserved(usage.total)
+		if i == 0 || left < headroom {
+			name, headroom = usage.name, left
+		}
+	}
+	return name, headroom, len(usages) > 0
+}
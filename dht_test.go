@@ -0,0 +1,78 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSubvolumeFillSkew(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	sizes := brickSizes(volumeStatus)
+
+	want := map[string]fillSkew{
+		"gv_arbiter":  {min: 0.62, max: 0.9, stddev: 0.14},
+		"gv_disperse": {min: 0.6, max: 0.6, stddev: 0},
+		"gv_dist":     {min: 0.1, max: 0.9, stddev: math.Sqrt(0.32 / 3)},
+	}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		skew, ok := subvolumeFillSkew(subvolumeUsages(volume, sizes))
+		w := want[volume.Name]
+		if !ok || math.Abs(skew.min-w.min) > 1e-9 || math.Abs(skew.max-w.max) > 1e-9 || math.Abs(skew.stddev-w.stddev) > 1e-9 {
+			t.Errorf("%v: want %+v, got %+v (%v)", volume.Name, w, skew, ok)
+		}
+	}
+
+	if _, ok := subvolumeFillSkew([]subvolumeUsage{{name: "gv_test-client-0"}}); ok {
+		t.Error("Expected no skew for subvolumes without size")
+	}
+}
+
+func TestParseMinFreeDisk(t *testing.T) {
+	tests := []struct {
+		value string
+		want  minFreeDisk
+		err   bool
+	}{
+		{"10%", minFreeDisk{percent: 10}, false},
+		{"12.5%", minFreeDisk{percent: 12.5}, false},
+		{"500MB", minFreeDisk{bytes: 500 << 20}, false},
+		{"2gb", minFreeDisk{bytes: 2 << 30}, false},
+		{"1048576", minFreeDisk{bytes: 1048576}, false},
+		{"1024B", minFreeDisk{bytes: 1024}, false},
+		{"120%", minFreeDisk{}, true},
+		{"lots", minFreeDisk{}, true},
+	}
+	for _, c := range tests {
+		got, err := parseMinFreeDisk(c.value)
+		if got != c.want || (err != nil) != c.err {
+			t.Errorf("parseMinFreeDisk(%q) == (%+v, %v), want %+v", c.value, got, err, c.want)
+		}
+	}
+}
+
+func TestClosestToMinFreeDisk(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	sizes := brickSizes(volumeStatus)
+
+	want := map[string]struct {
+		subvolume string
+		headroom  float64
+	}{
+		// default of 10%
+		"gv_arbiter":  {"gv_arbiter-replicate-1", 0},
+		"gv_disperse": {"gv_disperse-disperse-0", 60 * gib},
+		// cluster.min-free-disk is set to 20%
+		"gv_dist": {"gv_dist-client-0", -1 * gib},
+	}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		reserve, err := volumeMinFreeDisk(volume)
+		if err != nil {
+			t.Fatal(err)
+		}
+		subvolume, headroom, ok := closestToMinFreeDisk(subvolumeUsages(volume, sizes), reserve)
+		w := want[volume.Name]
+		if !ok || subvolume != w.subvolume || math.Abs(headroom-w.headroom) > 1 {
+			t.Errorf("%v: want %v with %v bytes, got %v with %v bytes (%v)", volume.Name, w.subvolume, w.headroom, subvolume, headroom, ok)
+		}
+	}
+}
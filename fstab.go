@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+)
+
+const (
+	// DefaultFstab is the file system table of the node
+	DefaultFstab = "/etc/fstab"
+
+	// fstab file system type of gluster fuse mounts
+	glusterFstabType = "glusterfs"
+)
+
+// fstabEntry is a gluster mount configured in the file system table
+type fstabEntry struct {
+	source     string
+	mountPoint string
+	options    []string
+}
+
+// readFstab returns the gluster mounts configured in a fstab file
+func readFstab(path string) ([]fstabEntry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseFstab(file)
+}
+
+// parseFstab parses the gluster mounts of a fstab file, other file systems, comments and incomplete lines are skipped
+func parseFstab(r io.Reader) ([]fstabEntry, error) {
+	var entries []fstabEntry
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 3 || fields[2] != glusterFstabType {
+			continue
+		}
+		entry := fstabEntry{source: unescapeMountinfo(fields[0]), mountPoint: unescapeMountinfo(fields[1])}
+		if len(fields) > 3 {
+			entry.options = strings.Split(fields[3], ",")
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// backupVolfileServers returns the servers of the backup-volfile-servers mount option, separated by colons,
+// or of its deprecated single server form backupvolfile-server
+func backupVolfileServers(options []string) []string {
+	var servers []string
+	for _, option := range options {
+		keyValue := strings.SplitN(option, "=", 2)
+		if len(keyValue) != 2 || len(keyValue[1]) == 0 {
+			continue
+		}
+		switch keyValue[0] {
+		case "backup-volfile-servers":
+			servers = append(servers, strings.Split(keyValue[1], ":")...)
+		case "backupvolfile-server":
+			servers = append(servers, keyValue[1])
+		}
+	}
+	return servers
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReadFstab(t *testing.T) {
+	entries, err := readFstab("test/fstab")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []fstabEntry{
+		{
+			source:     "node1.example.local:/gv_test",
+			mountPoint: "/mnt/gv_test",
+			options:    []string{"defaults", "_netdev", "backup-volfile-servers=node2.example.local:node3.example.local"},
+		},
+		{
+			source:     "node2.example.local:gv_test2",
+			mountPoint: "/mnt/gluster data",
+			options:    []string{"defaults", "_netdev", "backupvolfile-server=node3.example.local"},
+		},
+		{
+			source:     "node1.example.local:/gv_scratch",
+			mountPoint: "/mnt/scratch",
+		},
+	}
+	if !reflect.DeepEqual(entries, want) {
+		t.Errorf("want: %+v, got: %+v", want, entries)
+	}
+}
+
+func TestBackupVolfileServers(t *testing.T) {
+	tests := []struct {
+		options []string
+		servers []string
+	}{
+		{[]string{"defaults", "backup-volfile-servers=node2:node3"}, []string{"node2", "node3"}},
+		{[]string{"backupvolfile-server=node3", "_netdev"}, []string{"node3"}},
+		{[]string{"defaults", "backup-volfile-servers="}, nil},
+		{nil, nil},
+	}
+	for _, c := range tests {
+		if servers := backupVolfileServers(c.options); !reflect.DeepEqual(servers, c.servers) {
+			t.Errorf("backupVolfileServers(%v) == %v, want %v", c.options, servers, c.servers)
+		}
+	}
+}
@@ -26,21 +26,16 @@ func execGlusterCommand(arg ...string) (*bytes.Buffer, error) {
 	return stdoutBuffer, nil
 }
 
-func execMountCheck() (*bytes.Buffer, error) {
-	stdoutBuffer := &bytes.Buffer{}
-	mountCmd := exec.Command("mount", "-t", "fuse.glusterfs")
-
-	mountCmd.Stdout = stdoutBuffer
-
-	return stdoutBuffer, mountCmd.Run()
-}
-
 func execTouchOnVolumes(mountpoint string) (bool, error) {
-	testFileName := fmt.Sprintf("%v/%v_%v", mountpoint, "gluster_mount.test", time.Now())
-	_, createErr := os.Create(testFileName)
+	testFileName := fmt.Sprintf("%v/%v_%v", mountpoint, "gluster_mount.test", time.Now().UnixNano())
+	testFile, createErr := os.Create(testFileName)
 	if createErr != nil {
 		return false, createErr
 	}
+	if closeErr := testFile.Close(); closeErr != nil {
+		os.Remove(testFileName)
+		return false, closeErr
+	}
 	removeErr := os.Remove(testFileName)
 	if removeErr != nil {
 		return false, removeErr
@@ -174,3 +169,140 @@ func ExecVolumeQuotaList(volumeName string) (structs.VolumeQuotaXML, error) {
 	}
 	return volumeQuota, nil
 }
+
+// ExecVolumeQuotaListObjects executes "gluster volume quota {volume} list-objects" on host system and
+// returns VolumeQuotaObjectsXML struct and error
+func ExecVolumeQuotaListObjects(volumeName string) (structs.VolumeQuotaObjectsXML, error) {
+	args := []string{"volume", "quota", volumeName, "list-objects"}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolumeQuotaObjectsXML{}, cmdErr
+	}
+	volumeQuota, err := structs.VolumeQuotaListObjectsXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return volumeQuota, err
+	}
+	return volumeQuota, nil
+}
+
+// ExecVolumeGeoRepStatusDetail executes "gluster volume geo-replication status detail" at the local machine and
+// returns VolumeGeoRepStatusXML struct and error
+func ExecVolumeGeoRepStatusDetail() (structs.VolumeGeoRepStatusXML, error) {
+	args := []string{"volume", "geo-replication", "status", "detail"}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolumeGeoRepStatusXML{}, cmdErr
+	}
+	geoRepStatus, err := structs.VolumeGeoRepStatusXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return geoRepStatus, err
+	}
+	return geoRepStatus, nil
+}
+
+// ExecVolumeRebalanceStatus executes "gluster volume rebalance {volume} status" at the local machine and
+// returns VolRebalance struct and error
+func ExecVolumeRebalanceStatus(volumeName string) (structs.VolRebalance, error) {
+	args := []string{"volume", "rebalance", volumeName, "status"}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolRebalance{}, cmdErr
+	}
+	rebalanceStatus, err := structs.VolumeRebalanceStatusXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return rebalanceStatus.VolRebalance, err
+	}
+	return rebalanceStatus.VolRebalance, nil
+}
+
+// ExecVolumeRemoveBrickStatus executes "gluster volume remove-brick {volume} {bricks} status" at the local machine and
+// returns VolRebalance struct and error
+func ExecVolumeRemoveBrickStatus(volumeName string, bricks []string) (structs.VolRebalance, error) {
+	args := append([]string{"volume", "remove-brick", volumeName}, bricks...)
+	args = append(args, "status")
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolRebalance{}, cmdErr
+	}
+	removeBrickStatus, err := structs.VolumeRemoveBrickStatusXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return removeBrickStatus.VolRemoveBrick, err
+	}
+	return removeBrickStatus.VolRemoveBrick, nil
+}
+
+// ExecSnapshotInfo executes "gluster snapshot info" at the local machine and
+// returns SnapInfo struct and error
+func ExecSnapshotInfo() (structs.SnapInfo, error) {
+	args := []string{"snapshot", "info"}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.SnapInfo{}, cmdErr
+	}
+	snapInfo, err := structs.SnapshotInfoXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return snapInfo.SnapInfo, err
+	}
+	return snapInfo.SnapInfo, nil
+}
+
+// ExecSnapshotConfig executes "gluster snapshot config" at the local machine and
+// returns SnapConfig struct and error
+func ExecSnapshotConfig() (structs.SnapConfig, error) {
+	args := []string{"snapshot", "config"}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.SnapConfig{}, cmdErr
+	}
+	snapConfig, err := structs.SnapshotConfigXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return snapConfig.SnapConfig, err
+	}
+	return snapConfig.SnapConfig, nil
+}
+
+// ExecVolumeGetAll executes "gluster volume get {volume} all" at the local machine and
+// returns VolGetOpts struct and error
+func ExecVolumeGetAll(volumeName string) (structs.VolGetOpts, error) {
+	return ExecVolumeGet(volumeName, "all")
+}
+
+// ExecVolumeGet executes "gluster volume get {volume} {option}" at the local machine. The volume "all"
+// gets the global options of the cluster like cluster.server-quorum-ratio.
+// returns VolGetOpts struct and error
+func ExecVolumeGet(volumeName string, option string) (structs.VolGetOpts, error) {
+	args := []string{"volume", "get", volumeName, option}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolGetOpts{}, cmdErr
+	}
+	volGet, err := structs.VolumeGetXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return volGet.VolGetOpts, err
+	}
+	return volGet.VolGetOpts, nil
+}
+
+// ExecVolumeStatusAll executes "gluster volume status all {option}" at the local machine, where option is
+// one of clients, mem, inode, fd or callpool
+// returns VolumeStatusXML struct and error
+func ExecVolumeStatusAll(option string) (structs.VolumeStatusXML, error) {
+	args := []string{"volume", "status", "all", option}
+	bytesBuffer, cmdErr := execGlusterCommand(args...)
+	if cmdErr != nil {
+		return structs.VolumeStatusXML{}, cmdErr
+	}
+	volumeStatus, err := structs.VolumeStatusXMLUnmarshall(bytesBuffer)
+	if err != nil {
+		log.Errorf("Something went wrong while unmarshalling xml: %v", err)
+		return volumeStatus, err
+	}
+	return volumeStatus, nil
+}
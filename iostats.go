@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	// ioStatsDumpXattr makes the io-stats xlator of a fuse client dump its statistics when set on the mount
+	ioStatsDumpXattr = "trusted.io-stats-dump"
+
+	// ioStatsCumulativeHeader starts the statistics since the mount in a dump
+	ioStatsCumulativeHeader = "=== Cumulative stats ==="
+)
+
+// ioStatsFop holds hits and latencies in microseconds of a file operation in an io-stats dump
+type ioStatsFop struct {
+	name       string
+	hits       uint64
+	avgLatency float64
+	minLatency float64
+	maxLatency float64
+}
+
+// ioStatsDump holds the cumulative statistics of an io-stats dump
+type ioStatsDump struct {
+	bytesRead    uint64
+	bytesWritten uint64
+	fops         []ioStatsFop
+}
+
+// dumpIOStats makes the fuse client of a mount dump its io-stats into dir and parses the dump. The xattr value is
+// the file name of the dump, gluster writes it into its run directory.
+func dumpIOStats(mountPoint, dir string) (ioStatsDump, error) {
+	name := fmt.Sprintf("gluster_exporter.io-stats.%v", time.Now().UnixNano())
+	if err := syscall.Setxattr(mountPoint, ioStatsDumpXattr, []byte(name), 0); err != nil {
+		return ioStatsDump{}, &os.PathError{Op: "setxattr", Path: mountPoint, Err: err}
+	}
+	path := filepath.Join(dir, name)
+	defer os.Remove(path)
+	file, err := os.Open(path)
+	if err != nil {
+		return ioStatsDump{}, err
+	}
+	defer file.Close()
+	return parseIOStatsDump(file)
+}
+
+// parseIOStatsDump parses the cumulative section of an io-stats dump in text format. Fops are listed as
+//
+//	Fop           Call Count    Avg-Latency    Min-Latency    Max-Latency
+//	LOOKUP              2930      402.10 us      101.00 us    12000.00 us
+func parseIOStatsDump(r io.Reader) (ioStatsDump, error) {
+	var dump ioStatsDump
+	cumulative := false
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "===") {
+			cumulative = line == ioStatsCumulativeHeader
+			continue
+		}
+		if !cumulative {
+			continue
+		}
+		if keyValue := strings.SplitN(line, ":", 2); len(keyValue) == 2 {
+			fields := strings.Fields(keyValue[1])
+			if len(fields) == 0 {
+				continue
+			}
+			switch strings.TrimSpace(keyValue[0]) {
+			case "BytesRead":
+				dump.bytesRead, _ = strconv.ParseUint(fields[0], 10, 64)
+			case "BytesWritten":
+				dump.bytesWritten, _ = strconv.ParseUint(fields[0], 10, 64)
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) != 8 || fields[3] != "us" || fields[5] != "us" || fields[7] != "us" {
+			continue
+		}
+		hits, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return dump, fmt.Errorf("invalid call count of %v: %v", fields[0], err)
+		}
+		fop := ioStatsFop{name: fields[0], hits: hits}
+		for i, latency := range []*float64{&fop.avgLatency, &fop.minLatency, &fop.maxLatency} {
+			if *latency, err = strconv.ParseFloat(fields[2+2*i], 64); err != nil {
+				return dump, fmt.Errorf("invalid latency of %v: %v", fields[0], err)
+			}
+		}
+		dump.fops = append(dump.fops, fop)
+	}
+	return dump, scanner.Err()
+}
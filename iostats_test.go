@@ -0,0 +1,37 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestParseIOStatsDump(t *testing.T) {
+	file, err := os.Open("test/io_stats_dump.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	dump, err := parseIOStatsDump(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dump.bytesRead != 73400320 || dump.bytesWritten != 20971520 {
+		t.Errorf("Expected cumulative bytes 73400320 and 20971520, got %v and %v", dump.bytesRead, dump.bytesWritten)
+	}
+	if len(dump.fops) != 7 {
+		t.Fatalf("Expected 7 fops, got %v", len(dump.fops))
+	}
+	want := ioStatsFop{name: "LOOKUP", hits: 2930, avgLatency: 402.10, minLatency: 101, maxLatency: 12000}
+	if dump.fops[4] != want {
+		t.Errorf("want: %+v, got: %+v", want, dump.fops[4])
+	}
+}
+
+func TestParseIOStatsDumpInvalid(t *testing.T) {
+	dump := "=== Cumulative stats ===\nLOOKUP  2930  402.10 us  abc us  12000.00 us\n"
+	if _, err := parseIOStatsDump(strings.NewReader(dump)); err == nil {
+		t.Error("Expected error for invalid latency")
+	}
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// DefaultLvsPath is the path of the lvm reporting command
+	DefaultLvsPath = "/sbin/lvs"
+
+	// lvsFields are the fields requested from lvs, in the order parseLvs expects them
+	lvsFields = "vg_name,lv_name,lv_path,lv_dm_path,pool_lv,lv_attr,data_percent,metadata_percent"
+)
+
+// logicalVolume is a logical volume reported by lvs
+type logicalVolume struct {
+	vg     string
+	name   string
+	path   string
+	dmPath string
+	// pool is the thin pool of a thin volume
+	pool            string
+	attr            string
+	dataPercent     float64
+	metadataPercent float64
+}
+
+// thinPool reports whether the logical volume is a thin pool
+func (lv logicalVolume) thinPool() bool {
+	return strings.HasPrefix(lv.attr, "t")
+}
+
+// execLvs lists the logical volumes of the node
+func execLvs(lvsPath string) ([]logicalVolume, error) {
+	stdoutBuffer := &bytes.Buffer{}
+	lvsExec := exec.Command(lvsPath, "--noheadings", "--nosuffix", "--separator", "|", "-o", lvsFields)
+	// lvs formats percentages with the decimal separator of the locale
+	lvsExec.Env = append(os.Environ(), "LC_ALL=C")
+	lvsExec.Stdout = stdoutBuffer
+	if err := lvsExec.Run(); err != nil {
+		return nil, fmt.Errorf("tried to execute %v and got error: %v", lvsPath, err)
+	}
+	return parseLvs(stdoutBuffer)
+}
+
+// parseLvs parses the output of lvs with the fields of lvsFields separated by "|". Percentages are empty
+// for logical volumes which aren't thin.
+func parseLvs(r io.Reader) ([]logicalVolume, error) {
+	var lvs []logicalVolume
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if len(line) == 0 {
+			continue
+		}
+		fields := strings.Split(line, "|")
+		if len(fields) != 8 {
+			return lvs, fmt.Errorf("malformed lvs line: %q", line)
+		}
+		lv := logicalVolume{
+			vg:     fields[0],
+			name:   fields[1],
+			path:   fields[2],
+			dmPath: fields[3],
+			pool:   fields[4],
+			attr:   fields[5],
+		}
+		var err error
+		if lv.dataPercent, err = parseLvsPercent(fields[6]); err != nil {
+			return lvs, err
+		}
+		if lv.metadataPercent, err = parseLvsPercent(fields[7]); err != nil {
+			return lvs, err
+		}
+		lvs = append(lvs, lv)
+	}
+	return lvs, scanner.Err()
+}
+
+// parseLvsPercent parses a percentage of lvs, which is empty if it doesn't apply
+func parseLvsPercent(field string) (float64, error) {
+	field = strings.TrimSpace(field)
+	if len(field) == 0 {
+		return 0, nil
+	}
+	return strconv.ParseFloat(field, 64)
+}
+
+// findLogicalVolume returns the logical volume of a block device like /dev/mapper/VG-LV or /dev/VG/LV.
+// Both are symlinks to the same /dev/dm-N node, which is compared if the device isn't listed by lvs as is.
+func findLogicalVolume(device string, lvs []logicalVolume) (logicalVolume, bool) {
+	for _, lv := range lvs {
+		if device == lv.path || device == lv.dmPath {
+			return lv, true
+		}
+	}
+	resolved, err := filepath.EvalSymlinks(device)
+	if err != nil {
+		return logicalVolume{}, false
+	}
+	for _, lv := range lvs {
+		for _, path := range []string{lv.path, lv.dmPath} {
+			if len(path) == 0 {
+				continue
+			}
+			if target, err := filepath.EvalSymlinks(path); err == nil && target == resolved {
+				return lv, true
+			}
+		}
+	}
+	return logicalVolume{}, false
+}
+
+// findThinPool returns the thin pool of a thin volume
+func findThinPool(lv logicalVolume, lvs []logicalVolume) (logicalVolume, bool) {
+	if len(lv.pool) == 0 {
+		return logicalVolume{}, false
+	}
+	for _, pool := range lvs {
+		if pool.vg == lv.vg && pool.name == lv.pool && pool.thinPool() {
+			return pool, true
+		}
+	}
+	return logicalVolume{}, false
+}
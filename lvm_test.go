@@ -0,0 +1,65 @@
+package main
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func readLvsFixture(t *testing.T) []logicalVolume {
+	file, err := os.Open("test/lvs.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	lvs, err := parseLvs(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return lvs
+}
+
+func TestParseLvs(t *testing.T) {
+	lvs := readLvsFixture(t)
+	if want := 8; len(lvs) != want {
+		t.Fatalf("want %v logical volumes, got %v", want, len(lvs))
+	}
+	want := logicalVolume{vg: "gluster", name: "pool_dist", dmPath: "/dev/mapper/gluster-pool_dist", attr: "twi-aotz--", dataPercent: 91.04, metadataPercent: 76.18}
+	if lvs[5] != want || !lvs[5].thinPool() {
+		t.Errorf("want: %+v, got: %+v", want, lvs[5])
+	}
+
+	if _, err := parseLvs(strings.NewReader("  gluster|gv_dist|/dev/gluster/gv_dist\n")); err == nil {
+		t.Error("Expected error for malformed line")
+	}
+	if _, err := parseLvs(strings.NewReader("  gluster|pool|||twi-aotz--|63,35|11,72\n")); err == nil {
+		t.Error("Expected error for malformed percentage")
+	}
+}
+
+func TestFindThinPool(t *testing.T) {
+	lvs := readLvsFixture(t)
+	tests := []struct {
+		device string
+		lv     string
+		pool   string
+	}{
+		{"/dev/mapper/gluster-gv_arbiter_b1", "gv_arbiter_b1", "pool_arbiter"},
+		{"/dev/gluster/gv_dist", "gv_dist", "pool_dist"},
+		// thick logical volume
+		{"/dev/mapper/gluster-gv_disperse", "gv_disperse", ""},
+		// no logical volume
+		{"/dev/sdb1", "", ""},
+	}
+	for _, c := range tests {
+		lv, ok := findLogicalVolume(c.device, lvs)
+		if ok != (len(c.lv) > 0) || lv.name != c.lv {
+			t.Errorf("findLogicalVolume(%q) == (%q, %v), want %q", c.device, lv.name, ok, c.lv)
+			continue
+		}
+		pool, ok := findThinPool(lv, lvs)
+		if ok != (len(c.pool) > 0) || pool.name != c.pool {
+			t.Errorf("findThinPool(%q) == (%q, %v), want %q", lv.name, pool.name, ok, c.pool)
+		}
+	}
+}
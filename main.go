@@ -19,12 +19,17 @@ import (
 
 	"fmt"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/ofesseler/gluster_exporter/statedump"
+	"github.com/ofesseler/gluster_exporter/structs"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/prometheus/common/log"
 	"github.com/prometheus/common/version"
+	"github.com/prometheus/procfs"
 	"gopkg.in/alecthomas/kingpin.v2"
 )
 
@@ -147,6 +152,66 @@ var (
 		"Checks if mountpoint exists, returns a bool value 0 or 1",
 		[]string{"volume", "mountpoint"}, nil)
 
+	mountState = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_state"),
+		"State of a mount by the last probe (ok, hung, not_connected, read_only, permission_denied, error), 1 for the current state",
+		[]string{"volume", "mountpoint", "state"}, nil)
+
+	mountDataRead = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_data_read_bytes_total"),
+		"Total amount of bytes of data read by the fuse client of a mount",
+		[]string{"volume", "mountpoint"}, nil)
+
+	mountDataWritten = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_data_written_bytes_total"),
+		"Total amount of bytes of data written by the fuse client of a mount",
+		[]string{"volume", "mountpoint"}, nil)
+
+	mountFopHits = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_fop_hits_total"),
+		"Total amount of file operation hits of the fuse client of a mount",
+		[]string{"volume", "mountpoint", "fop_name"}, nil)
+
+	mountFopLatencyAvg = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_fop_latency_avg"),
+		"Average file operation latency of the fuse client of a mount since it was mounted",
+		[]string{"volume", "mountpoint", "fop_name"}, nil)
+
+	mountFopLatencyMin = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_fop_latency_min"),
+		"Minimum file operation latency of the fuse client of a mount since it was mounted",
+		[]string{"volume", "mountpoint", "fop_name"}, nil)
+
+	mountFopLatencyMax = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_fop_latency_max"),
+		"Maximum file operation latency of the fuse client of a mount since it was mounted",
+		[]string{"volume", "mountpoint", "fop_name"}, nil)
+
+	mountVolfileServer = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_volfile_server_info"),
+		"Volfile server a mount was mounted from, always 1",
+		[]string{"volume", "mountpoint", "server"}, nil)
+
+	mountBackupVolfileServers = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_backup_volfile_servers"),
+		"Number of backup volfile servers the fuse client of a mount was started with",
+		[]string{"volume", "mountpoint"}, nil)
+
+	mountFstabBackupVolfileServers = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_fstab_backup_volfile_servers"),
+		"Number of backup volfile servers configured for a mount in fstab",
+		[]string{"volume", "mountpoint"}, nil)
+
+	mountInFstab = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_in_fstab"),
+		"Whether a mount is configured in fstab, returns a bool value 0 or 1",
+		[]string{"volume", "mountpoint"}, nil)
+
+	mountVolumeExists = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "mount_volume_exists"),
+		"Whether the volume of a mount exists in 'gluster volume info', returns a bool value 0 or 1",
+		[]string{"volume", "mountpoint"}, nil)
+
 	quotaHardLimit = prometheus.NewDesc(
 		prometheus.BuildFQName(namespace, "", "volume_quota_hardlimit"),
 		"Quota hard limit (bytes) in a volume",
@@ -176,15 +241,485 @@ var (
 		prometheus.BuildFQName(namespace, "", "volume_quota_hardlimit_exceeded"),
 		"Is the quota hard-limit exceeded",
 		[]string{"path", "volume"}, nil)
+
+	quotaUsage = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_usage_ratio"),
+		"Ratio of the used data to the quota hard limit",
+		[]string{"path", "volume"}, nil)
+
+	quotaObjectsHardLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_objects_hardlimit"),
+		"Quota hard limit of files and directories in a volume",
+		[]string{"path", "volume"}, nil)
+
+	quotaObjectsSoftLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_objects_softlimit"),
+		"Quota soft limit of files and directories in a volume",
+		[]string{"path", "volume"}, nil)
+
+	quotaFiles = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_files"),
+		"Current number of files in an object quota",
+		[]string{"path", "volume"}, nil)
+
+	quotaDirs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_dirs"),
+		"Current number of directories in an object quota",
+		[]string{"path", "volume"}, nil)
+
+	quotaObjectsAvailable = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_objects_available"),
+		"Current number of files and directories available in an object quota",
+		[]string{"path", "volume"}, nil)
+
+	quotaObjectsUsage = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_objects_usage_ratio"),
+		"Ratio of the files and directories to the object quota hard limit",
+		[]string{"path", "volume"}, nil)
+
+	quotaPathsDropped = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_quota_paths_dropped"),
+		"Number of quota paths currently not exported because of --quota.max-paths",
+		[]string{"volume", "quota"}, nil)
+
+	geoRepWorkerStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_worker_status"),
+		"Status of a geo-replication worker (Active, Passive, Faulty, ...), always 1 for the reported status",
+		[]string{"volume", "slave", "master_node", "master_brick", "status"}, nil)
+
+	geoRepCrawlStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_crawl_status"),
+		"Crawl status of a geo-replication worker, always 1 for the reported crawl status",
+		[]string{"volume", "slave", "master_node", "master_brick", "crawl_status"}, nil)
+
+	geoRepLastSynced = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_last_synced_timestamp_seconds"),
+		"Unix timestamp of the last time a geo-replication worker synced to the slave",
+		[]string{"volume", "slave", "master_node", "master_brick"}, nil)
+
+	geoRepEntryPending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_entry_pending"),
+		"Number of entry operations pending to be synced by a geo-replication worker",
+		[]string{"volume", "slave", "master_node", "master_brick"}, nil)
+
+	geoRepDataPending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_data_pending"),
+		"Number of data operations pending to be synced by a geo-replication worker",
+		[]string{"volume", "slave", "master_node", "master_brick"}, nil)
+
+	geoRepMetaPending = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_meta_pending"),
+		"Number of meta operations pending to be synced by a geo-replication worker",
+		[]string{"volume", "slave", "master_node", "master_brick"}, nil)
+
+	geoRepFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "georep_failures"),
+		"Number of failures of a geo-replication worker",
+		[]string{"volume", "slave", "master_node", "master_brick"}, nil)
+
+	volumeTaskStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_task_status"),
+		"Status code of a task reported by 'gluster volume status', e.g. rebalance or remove-brick",
+		[]string{"volume", "type", "id"}, nil)
+
+	migrationFilesScanned = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_files_scanned"),
+		"Number of files scanned by a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationFilesMigrated = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_files_migrated"),
+		"Number of files migrated by a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationDataBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_data_bytes"),
+		"Amount of bytes moved by a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationFailures = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_failures"),
+		"Number of failed file migrations of a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationFilesSkipped = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_files_skipped"),
+		"Number of files skipped by a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationRuntime = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_runtime_seconds"),
+		"Run time of a rebalance or remove-brick task on a node in seconds",
+		[]string{"volume", "task", "node"}, nil)
+
+	migrationStatus = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_migration_status"),
+		"Status code of a rebalance or remove-brick task on a node",
+		[]string{"volume", "task", "node"}, nil)
+
+	volumeSnapshotCount = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_count"),
+		"Number of snapshots of a volume",
+		[]string{"volume"}, nil)
+
+	volumeSnapshotRemaining = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_remaining"),
+		"Number of snapshots which can still be taken of a volume",
+		[]string{"volume"}, nil)
+
+	volumeSnapshotHardLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_hardlimit"),
+		"Effective snap-max-hard-limit of a volume",
+		[]string{"volume"}, nil)
+
+	volumeSnapshotSoftLimit = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_snapshot_softlimit"),
+		"Number of snapshots of a volume at which the snap-max-soft-limit is reached",
+		[]string{"volume"}, nil)
+
+	snapshotCreated = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapshot_created_timestamp_seconds"),
+		"Unix timestamp of the creation of a snapshot",
+		[]string{"volume", "snapshot"}, nil)
+
+	snapshotActivated = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "snapshot_activated"),
+		"Is the snapshot activated, returns a bool value 0 or 1",
+		[]string{"volume", "snapshot"}, nil)
+
+	volumeOptionValue = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_option_value"),
+		"Value of a numeric volume option reported by 'gluster volume get VOLNAME all'",
+		[]string{"volume", "option"}, nil)
+
+	volumeOptionInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_option_info"),
+		"Value of a non numeric volume option reported by 'gluster volume get VOLNAME all', always 1",
+		[]string{"volume", "option", "value"}, nil)
+
+	volumeOptionCompliant = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_option_compliant"),
+		"Does the volume option match the value expected by the volume option policy, returns a bool value 0 or 1",
+		[]string{"volume", "option"}, nil)
+
+	volumeOptionViolations = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_option_violations"),
+		"Number of volume options not matching the value expected by the volume option policy",
+		[]string{"volume"}, nil)
+
+	brickClientsConnected = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_clients_connected"),
+		"Number of clients connected to a brick",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickClientConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_client_connections"),
+		"Number of connections of a client host to a brick",
+		[]string{"hostname", "path", "volume", "client"}, nil)
+
+	brickClientDataRead = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_client_data_read_bytes"),
+		"Bytes of data read by the open connections of a client host from a brick, drops when a connection closes",
+		[]string{"hostname", "path", "volume", "client"}, nil)
+
+	brickClientDataWritten = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_client_data_written_bytes"),
+		"Bytes of data written by the open connections of a client host to a brick, drops when a connection closes",
+		[]string{"hostname", "path", "volume", "client"}, nil)
+
+	brickClientOpVersion = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_client_op_version"),
+		"Lowest op-version of the connections of a client host to a brick",
+		[]string{"hostname", "path", "volume", "client"}, nil)
+
+	brickMallinfoArena = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mallinfo_arena_bytes"),
+		"Bytes of memory allocated with sbrk by a brick process (mallinfo arena)",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickMallinfoMmap = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mallinfo_mmap_bytes"),
+		"Bytes of memory allocated with mmap by a brick process (mallinfo hblkhd)",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickMallinfoUsed = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mallinfo_used_bytes"),
+		"Bytes of memory in use by a brick process (mallinfo uordblks)",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickMallinfoFree = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mallinfo_free_bytes"),
+		"Bytes of memory allocated but free in a brick process (mallinfo fordblks)",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickMempoolHot = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mempool_hot_count"),
+		"Number of objects in use of a memory pool of a brick process",
+		[]string{"hostname", "path", "volume", "pool"}, nil)
+
+	brickMempoolCold = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mempool_cold_count"),
+		"Number of objects available of a memory pool of a brick process",
+		[]string{"hostname", "path", "volume", "pool"}, nil)
+
+	brickMempoolAllocs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mempool_allocs_total"),
+		"Total number of allocations from a memory pool of a brick process",
+		[]string{"hostname", "path", "volume", "pool"}, nil)
+
+	brickMempoolMisses = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_mempool_misses_total"),
+		"Total number of allocations which missed a memory pool of a brick process",
+		[]string{"hostname", "path", "volume", "pool"}, nil)
+
+	brickInodesActive = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_inodes_active"),
+		"Number of active inodes in the inode tables of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickInodesLRU = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_inodes_lru"),
+		"Number of inodes in the LRU list of the inode tables of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickInodesPurge = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_inodes_purge"),
+		"Number of inodes to be purged from the inode tables of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickFdConnections = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_fd_tables"),
+		"Number of fd tables, one per client connection, of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickFdsOpen = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_fds_open"),
+		"Number of open fds in all fd tables of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickCallPoolCalls = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_callpool_pending_calls"),
+		"Number of pending call stacks in the call pool of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickCallPoolFrames = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_callpool_pending_frames"),
+		"Number of frames of all pending call stacks in the call pool of a brick process",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickCallPoolOldest = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_callpool_oldest_call_age_seconds"),
+		"Seconds since the oldest pending call stack of a brick process was seen first by the exporter",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	statedumpTimestamp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statedump_timestamp_seconds"),
+		"Unix timestamp of the latest statedump of a gluster process",
+		[]string{"process"}, nil)
+
+	statedumpXlatorMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statedump_xlator_memory_bytes"),
+		"Bytes of memory accounted to a xlator in the latest statedump of a gluster process",
+		[]string{"process", "xlator"}, nil)
+
+	statedumpXlatorAllocs = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statedump_xlator_allocations"),
+		"Number of allocations accounted to a xlator in the latest statedump of a gluster process",
+		[]string{"process", "xlator"}, nil)
+
+	statedumpLocks = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statedump_locks"),
+		"Number of locks by type and state in the latest statedump of a gluster process",
+		[]string{"process", "xlator", "type", "state"}, nil)
+
+	statedumpCallStacks = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "statedump_call_stacks"),
+		"Number of pending call stacks in the latest statedump of a gluster process",
+		[]string{"process"}, nil)
+
+	brickProcessInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_process_info"),
+		"Pid of the glusterfsd process serving a brick, always 1",
+		[]string{"hostname", "path", "volume", "pid"}, nil)
+
+	brickProcessBricks = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_process_bricks"),
+		"Number of bricks served by a glusterfsd process",
+		[]string{"hostname", "pid"}, nil)
+
+	brickMultiplexing = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_multiplexing"),
+		"Whether bricks of a node share a glusterfsd process, 1 if cluster.brick-multiplex is in effect",
+		[]string{"hostname"}, nil)
+
+	deviceSizeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "device_size_bytes"),
+		"Size of a file system holding bricks in bytes, reported once however many bricks share it",
+		[]string{"hostname", "device"}, nil)
+
+	deviceFreeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "device_free_bytes"),
+		"Free bytes of a file system holding bricks, reported once however many bricks share it",
+		[]string{"hostname", "device"}, nil)
+
+	deviceBricksCount = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "device_bricks"),
+		"Number of bricks on a file system",
+		[]string{"hostname", "device"}, nil)
+
+	brickSharedFilesystem = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_shared_filesystem"),
+		"Whether a brick shares its file system with other bricks, 1 if its size is also reported by them",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickThinProvisioned = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_thin_provisioned"),
+		"Whether a brick of this node is on a thin logical volume, which gluster snapshots require, returns a bool value 0 or 1",
+		[]string{"hostname", "path", "volume"}, nil)
+
+	brickThinPoolInfo = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "brick_thin_pool_info"),
+		"Thin logical volume and thin pool of a brick of this node, always 1",
+		[]string{"hostname", "path", "volume", "vg", "lv", "pool"}, nil)
+
+	thinPoolDataPercent = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "thin_pool_data_percent"),
+		"Used data space of a thin pool holding bricks in percent",
+		[]string{"hostname", "vg", "pool"}, nil)
+
+	thinPoolMetadataPercent = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "thin_pool_metadata_percent"),
+		"Used metadata space of a thin pool holding bricks in percent",
+		[]string{"hostname", "vg", "pool"}, nil)
+
+	subvolumeBricks = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "subvolume_bricks"),
+		"Number of bricks of a replica or disperse set",
+		[]string{"volume", "subvolume"}, nil)
+
+	subvolumeBricksUp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "subvolume_bricks_up"),
+		"Number of online bricks of a replica or disperse set",
+		[]string{"volume", "subvolume"}, nil)
+
+	volumeDisperseRedundancy = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_disperse_redundancy"),
+		"Number of bricks of each disperse set of a volume which may fail without losing data",
+		[]string{"volume"}, nil)
+
+	disperseSubvolumeFailuresTolerated = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "disperse_subvolume_failures_tolerated"),
+		"Number of further bricks of a disperse set which may fail before its data becomes unavailable, negative once it is",
+		[]string{"volume", "subvolume"}, nil)
+
+	replicaSubvolumeArbiterUp = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "replica_subvolume_arbiter_up"),
+		"Whether the arbiter brick of a replica set is online, returns a bool value 0 or 1",
+		[]string{"volume", "subvolume"}, nil)
+
+	replicaSubvolumeQuorumMet = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "replica_subvolume_quorum_met"),
+		"Whether a replica set meets client quorum and accepts writes, returns a bool value 0 or 1",
+		[]string{"volume", "subvolume"}, nil)
+
+	serverQuorumMet = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "server_quorum_met"),
+		"Whether the trusted pool meets server quorum from the view of this node, returns a bool value 0 or 1",
+		nil, nil)
+
+	volumeServerQuorumEnforced = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_server_quorum_enforced"),
+		"Whether glusterd stops the bricks of a volume on nodes without server quorum, returns a bool value 0 or 1",
+		[]string{"volume"}, nil)
+
+	volumeCapacityBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_capacity_bytes"),
+		"Bytes clients can store in a volume, accounting for replica, arbiter and disperse bricks",
+		[]string{"volume"}, nil)
+
+	volumeFreeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_free_bytes"),
+		"Free bytes clients can still store in a volume, accounting for replica, arbiter and disperse bricks",
+		[]string{"volume"}, nil)
+
+	volumeSubvolumeMinFreeBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_subvolume_min_free_bytes"),
+		"Free bytes of the fullest replica or disperse set of a volume, files are placed on a single set",
+		[]string{"volume"}, nil)
+
+	volumeSubvolumeUsedRatioMin = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_subvolume_used_ratio_min"),
+		"Used ratio of the emptiest replica or disperse set of a volume",
+		[]string{"volume"}, nil)
+
+	volumeSubvolumeUsedRatioMax = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_subvolume_used_ratio_max"),
+		"Used ratio of the fullest replica or disperse set of a volume",
+		[]string{"volume"}, nil)
+
+	volumeSubvolumeUsedRatioStddev = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_subvolume_used_ratio_stddev"),
+		"Standard deviation of the used ratio of the replica or disperse sets of a volume",
+		[]string{"volume"}, nil)
+
+	volumeMinFreeDiskHeadroomBytes = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "volume_min_free_disk_headroom_bytes"),
+		"Free bytes above cluster.min-free-disk of the subvolume closest to it, negative once DHT avoids the subvolume",
+		[]string{"volume", "subvolume"}, nil)
+
+	processCPUSeconds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "process_cpu_seconds_total"),
+		"Total user and system CPU time spent by a gluster process in seconds",
+		[]string{"role", "volume", "path"}, nil)
+
+	processResidentMemory = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "process_resident_memory_bytes"),
+		"Resident memory size of a gluster process in bytes",
+		[]string{"role", "volume", "path"}, nil)
+
+	processOpenFds = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "process_open_fds"),
+		"Number of open file descriptors of a gluster process",
+		[]string{"role", "volume", "path"}, nil)
+
+	processThreads = prometheus.NewDesc(
+		prometheus.BuildFQName(namespace, "", "process_threads"),
+		"Number of threads of a gluster process",
+		[]string{"role", "volume", "path"}, nil)
+)
+
+const (
+	// task types as reported by "gluster volume status"
+	taskTypeRebalance   = "Rebalance"
+	taskTypeRemoveBrick = "Remove brick"
 )
 
 // Exporter holds name, path and volumes to be monitored
 type Exporter struct {
-	hostname string
-	path     string
-	volumes  []string
-	profile  bool
-	quota    bool
+	hostname      string
+	path          string
+	volumes       []string
+	profile       bool
+	quota         bool
+	quotaCounters bool
+	quotaPaths    *quotaPathFilter
+	georep        bool
+	rebalance     bool
+	snapshot      bool
+	options       []string
+	policy        *OptionPolicyFile
+	clients       bool
+	resources     bool
+	callpool      *callStackTracker
+	statedump     *statedumpReader
+	processes     bool
+	mountProber   *mountProber
+	roundTrip     *roundTripProbe
+	metadata      *metadataProbe
+	ioStatsDir    string
+	fstab         string
+	lvs           string
+	pidfile       string
 }
 
 // Describe all the metrics exported by Gluster exporter. It implements prometheus.Collector.
@@ -206,12 +741,121 @@ func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
 	ch <- healInfoFilesCount
 	ch <- volumeWriteable
 	ch <- mountSuccessful
+	ch <- mountState
+	ch <- mountVolfileServer
+	ch <- mountBackupVolfileServers
+	ch <- mountFstabBackupVolfileServers
+	ch <- mountInFstab
+	ch <- mountVolumeExists
+	ch <- mountDataRead
+	ch <- mountDataWritten
+	ch <- mountFopHits
+	ch <- mountFopLatencyAvg
+	ch <- mountFopLatencyMin
+	ch <- mountFopLatencyMax
+	if e.roundTrip != nil {
+		e.roundTrip.latency.Describe(ch)
+		e.roundTrip.failures.Describe(ch)
+	}
+	if e.metadata != nil {
+		e.metadata.latency.Describe(ch)
+		e.metadata.errors.Describe(ch)
+	}
 	ch <- quotaHardLimit
 	ch <- quotaSoftLimit
 	ch <- quotaUsed
 	ch <- quotaAvailable
 	ch <- quotaSoftLimitExceeded
 	ch <- quotaHardLimitExceeded
+	ch <- quotaUsage
+	ch <- quotaObjectsHardLimit
+	ch <- quotaObjectsSoftLimit
+	ch <- quotaFiles
+	ch <- quotaDirs
+	ch <- quotaObjectsAvailable
+	ch <- quotaObjectsUsage
+	ch <- quotaPathsDropped
+	ch <- geoRepWorkerStatus
+	ch <- geoRepCrawlStatus
+	ch <- geoRepLastSynced
+	ch <- geoRepEntryPending
+	ch <- geoRepDataPending
+	ch <- geoRepMetaPending
+	ch <- geoRepFailures
+	ch <- volumeTaskStatus
+	ch <- migrationFilesScanned
+	ch <- migrationFilesMigrated
+	ch <- migrationDataBytes
+	ch <- migrationFailures
+	ch <- migrationFilesSkipped
+	ch <- migrationRuntime
+	ch <- migrationStatus
+	ch <- volumeSnapshotCount
+	ch <- volumeSnapshotRemaining
+	ch <- volumeSnapshotHardLimit
+	ch <- volumeSnapshotSoftLimit
+	ch <- snapshotCreated
+	ch <- snapshotActivated
+	ch <- volumeOptionValue
+	ch <- volumeOptionInfo
+	ch <- volumeOptionCompliant
+	ch <- volumeOptionViolations
+	ch <- brickClientsConnected
+	ch <- brickClientConnections
+	ch <- brickClientDataRead
+	ch <- brickClientDataWritten
+	ch <- brickClientOpVersion
+	ch <- brickMallinfoArena
+	ch <- brickMallinfoMmap
+	ch <- brickMallinfoUsed
+	ch <- brickMallinfoFree
+	ch <- brickMempoolHot
+	ch <- brickMempoolCold
+	ch <- brickMempoolAllocs
+	ch <- brickMempoolMisses
+	ch <- brickInodesActive
+	ch <- brickInodesLRU
+	ch <- brickInodesPurge
+	ch <- brickFdConnections
+	ch <- brickFdsOpen
+	ch <- brickCallPoolCalls
+	ch <- brickCallPoolFrames
+	ch <- brickCallPoolOldest
+	ch <- statedumpTimestamp
+	ch <- statedumpXlatorMemory
+	ch <- statedumpXlatorAllocs
+	ch <- statedumpLocks
+	ch <- statedumpCallStacks
+	ch <- brickProcessInfo
+	ch <- brickProcessBricks
+	ch <- brickMultiplexing
+	ch <- deviceSizeBytes
+	ch <- deviceFreeBytes
+	ch <- deviceBricksCount
+	ch <- brickSharedFilesystem
+	ch <- brickThinProvisioned
+	ch <- brickThinPoolInfo
+	ch <- thinPoolDataPercent
+	ch <- thinPoolMetadataPercent
+	ch <- subvolumeBricks
+	ch <- subvolumeBricksUp
+	ch <- volumeDisperseRedundancy
+	ch <- disperseSubvolumeFailuresTolerated
+	ch <- replicaSubvolumeArbiterUp
+	ch <- replicaSubvolumeQuorumMet
+	ch <- serverQuorumMet
+	ch <- volumeServerQuorumEnforced
+	ch <- volumeCapacityBytes
+	ch <- volumeFreeBytes
+	ch <- volumeSubvolumeMinFreeBytes
+	ch <- volumeSubvolumeUsedRatioMin
+	ch <- volumeSubvolumeUsedRatioMax
+	ch <- volumeSubvolumeUsedRatioStddev
+	ch <- volumeMinFreeDiskHeadroomBytes
+	ch <- processCPUSeconds
+	ch <- processResidentMemory
+	ch <- processOpenFds
+	ch <- processThreads
 }
 
 // Collect collects all the metrics
@@ -236,6 +880,7 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 			up, prometheus.GaugeValue, 1.0,
 		)
 	}
+	volumeInfoOK := err == nil && volumeInfo.OpErrno == 0
 
 	ch <- prometheus.MustNewConstMetric(
 		volumesCount, prometheus.GaugeValue, float64(volumeInfo.VolInfo.Volumes.Count),
@@ -316,8 +961,30 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 	if err != nil {
 		log.Errorf("couldn't parse xml of peer status: %v", err)
 	}
+	volumeStatusOK := err == nil && volumeStatusAll.OpErrno == 0
+	devices, deviceBricks := deviceSizes(volumeStatusAll)
+	for device, size := range devices {
+		ch <- prometheus.MustNewConstMetric(
+			deviceSizeBytes, prometheus.GaugeValue, float64(size.total), device.hostname, device.device,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			deviceFreeBytes, prometheus.GaugeValue, float64(size.free), device.hostname, device.device,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			deviceBricksCount, prometheus.GaugeValue, float64(deviceBricks[device]), device.hostname, device.device,
+		)
+	}
 	for _, vol := range volumeStatusAll.VolStatus.Volumes.Volume {
 		for _, node := range vol.Node {
+			if bricks, ok := deviceBricks[brickDevice{hostname: node.Hostname, device: node.Device}]; ok {
+				shared := 0.0
+				if bricks > 1 {
+					shared = 1.0
+				}
+				ch <- prometheus.MustNewConstMetric(
+					brickSharedFilesystem, prometheus.GaugeValue, shared, node.Hostname, node.Path, vol.VolName,
+				)
+			}
 			ch <- prometheus.MustNewConstMetric(
 				nodeSizeTotalBytes, prometheus.CounterValue, float64(node.SizeTotal), node.Hostname, node.Path, vol.VolName,
 			)
@@ -333,6 +1000,186 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 				nodeInodesFree, prometheus.GaugeValue, float64(node.InodesFree), node.Hostname, node.Path, vol.VolName,
 			)
 		}
+
+		for _, task := range vol.Tasks.Task {
+			ch <- prometheus.MustNewConstMetric(
+				volumeTaskStatus, prometheus.GaugeValue, float64(task.Status), vol.VolName, task.Type, task.ID,
+			)
+
+			if !e.rebalance || (e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName)) {
+				continue
+			}
+			switch task.Type {
+			case taskTypeRebalance:
+				rebalanceStatus, err := ExecVolumeRebalanceStatus(vol.VolName)
+				if err != nil {
+					log.Errorf("couldn't parse xml of rebalance status: %v", err)
+					continue
+				}
+				collectMigrationStatus(ch, vol.VolName, "rebalance", rebalanceStatus)
+			case taskTypeRemoveBrick:
+				removeBrickStatus, err := ExecVolumeRemoveBrickStatus(vol.VolName, task.Params.Brick)
+				if err != nil {
+					log.Errorf("couldn't parse xml of remove-brick status: %v", err)
+					continue
+				}
+				collectMigrationStatus(ch, vol.VolName, "remove-brick", removeBrickStatus)
+			}
+		}
+	}
+	for _, vol := range volumeStatusAll.VolStatus.Volumes.Volume {
+		if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+			continue
+		}
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Pid <= 0 {
+				continue
+			}
+			ch <- prometheus.MustNewConstMetric(
+				brickProcessInfo, prometheus.GaugeValue, 1, node.Hostname, node.Path, vol.VolName, strconv.Itoa(node.Pid),
+			)
+		}
+	}
+	brickProcesses := countBrickProcesses(volumeStatusAll)
+	for process, bricks := range brickProcesses {
+		ch <- prometheus.MustNewConstMetric(
+			brickProcessBricks, prometheus.GaugeValue, float64(bricks), process.hostname, strconv.Itoa(process.pid),
+		)
+	}
+	for hostname, shared := range multiplexedHosts(brickProcesses) {
+		multiplexed := 0.0
+		if shared {
+			multiplexed = 1.0
+		}
+		ch <- prometheus.MustNewConstMetric(
+			brickMultiplexing, prometheus.GaugeValue, multiplexed, hostname,
+		)
+	}
+	sizes := brickSizes(volumeStatusAll)
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volume.Name) {
+			continue
+		}
+		capacity, ok := usableCapacity(volume, sizes)
+		if !ok {
+			continue
+		}
+		ch <- prometheus.MustNewConstMetric(
+			volumeCapacityBytes, prometheus.GaugeValue, float64(capacity.total), volume.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			volumeFreeBytes, prometheus.GaugeValue, float64(capacity.free), volume.Name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			volumeSubvolumeMinFreeBytes, prometheus.GaugeValue, float64(capacity.minSubvolumeFree), volume.Name,
+		)
+
+		usages := subvolumeUsages(volume, sizes)
+		if skew, ok := subvolumeFillSkew(usages); ok {
+			ch <- prometheus.MustNewConstMetric(
+				volumeSubvolumeUsedRatioMin, prometheus.GaugeValue, skew.min, volume.Name,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				volumeSubvolumeUsedRatioMax, prometheus.GaugeValue, skew.max, volume.Name,
+			)
+			ch <- prometheus.MustNewConstMetric(
+				volumeSubvolumeUsedRatioStddev, prometheus.GaugeValue, skew.stddev, volume.Name,
+			)
+		}
+		reserve, err := volumeMinFreeDisk(volume)
+		if err != nil {
+			log.Errorf("couldn't parse options of volume %v: %v", volume.Name, err)
+			continue
+		}
+		if subvolume, headroom, ok := closestToMinFreeDisk(usages, reserve); ok {
+			ch <- prometheus.MustNewConstMetric(
+				volumeMinFreeDiskHeadroomBytes, prometheus.GaugeValue, headroom, volume.Name, subvolume,
+			)
+		}
+	}
+	if len(e.lvs) > 0 {
+		e.collectThinPools(ch, volumeStatusAll)
+	}
+	if volumeStatusOK {
+		online := onlineBricks(volumeStatusAll)
+		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+			if !redundantVolume(volume) || (e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volume.Name)) {
+				continue
+			}
+			dispersed := volume.DisperseCount > 0
+			replicated := !dispersed
+			var quorum clientQuorum
+			var quorumErr error
+			if replicated {
+				if quorum, quorumErr = volumeClientQuorum(volume); quorumErr != nil {
+					log.Errorf("couldn't parse options of volume %v: %v", volume.Name, quorumErr)
+				}
+			} else {
+				ch <- prometheus.MustNewConstMetric(
+					volumeDisperseRedundancy, prometheus.GaugeValue, float64(volume.RedundancyCount), volume.Name,
+				)
+			}
+			for _, health := range subvolumeHealths(volume, online) {
+				ch <- prometheus.MustNewConstMetric(
+					subvolumeBricks, prometheus.GaugeValue, float64(health.bricks), volume.Name, health.name,
+				)
+				ch <- prometheus.MustNewConstMetric(
+					subvolumeBricksUp, prometheus.GaugeValue, float64(health.up), volume.Name, health.name,
+				)
+				if dispersed {
+					ch <- prometheus.MustNewConstMetric(
+						disperseSubvolumeFailuresTolerated, prometheus.GaugeValue, float64(disperseFailuresTolerated(volume, health)), volume.Name, health.name,
+					)
+					continue
+				}
+				if health.arbiter {
+					arbiterUp := 0.0
+					if health.arbiterUp {
+						arbiterUp = 1.0
+					}
+					ch <- prometheus.MustNewConstMetric(
+						replicaSubvolumeArbiterUp, prometheus.GaugeValue, arbiterUp, volume.Name, health.name,
+					)
+				}
+				if quorumErr == nil {
+					met := 0.0
+					if quorum.met(health) {
+						met = 1.0
+					}
+					ch <- prometheus.MustNewConstMetric(
+						replicaSubvolumeQuorumMet, prometheus.GaugeValue, met, volume.Name, health.name,
+					)
+				}
+			}
+		}
+	}
+	if volumeInfoOK && peerStatusErr == nil {
+		globalOptions, err := ExecVolumeGet("all", serverQuorumRatioOption)
+		if err != nil {
+			log.Errorf("couldn't get %v: %v", serverQuorumRatioOption, err)
+		} else if met, err := hasServerQuorum(peerStatus.Peer, serverQuorumRatio(globalOptions)); err != nil {
+			log.Errorf("couldn't evaluate server quorum: %v", err)
+		} else {
+			quorum := 0.0
+			if met {
+				quorum = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				serverQuorumMet, prometheus.GaugeValue, quorum,
+			)
+		}
+		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volume.Name) {
+				continue
+			}
+			enforced := 0.0
+			if serverQuorumEnforced(volume) {
+				enforced = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				volumeServerQuorumEnforced, prometheus.GaugeValue, enforced, volume.Name,
+			)
+		}
 	}
 	vols := e.volumes
 	if vols[0] == allVolumes {
@@ -353,128 +1200,827 @@ func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
 		}
 	}
 
-	mountBuffer, execMountCheckErr := execMountCheck()
-	if execMountCheckErr != nil {
-		log.Error(execMountCheckErr)
-	} else {
-		mounts, err := parseMountOutput(mountBuffer.String())
+	mounts, err := readGlusterMounts(mountinfoPath)
+	if err != nil {
+		log.Errorf("couldn't read gluster mounts: %v", err)
+	}
+	mountsOK := err == nil
+	probes := make(map[string]func(string) error, len(mounts))
+	mounted := make(map[probedMount]bool, len(mounts))
+	for _, mount := range mounts {
+		probes[mount.mountPoint] = e.mountProbe(mount.volume)
+		mounted[probedMount{volume: mount.volume, mountPoint: mount.mountPoint}] = true
+	}
+	results := e.mountProber.probeAll(probes)
+	// dumping io-stats can block on a hung mount like any other probe
+	dumps := make([]ioStatsDump, len(mounts))
+	dumpProbes := make(map[string]func(string) error)
+	for i, mount := range mounts {
+		ch <- prometheus.MustNewConstMetric(
+			mountSuccessful, prometheus.GaugeValue, float64(1), mount.source, mount.mountPoint,
+		)
+
+		state, err := results[mount.mountPoint].state, results[mount.mountPoint].err
 		if err != nil {
-			log.Error(err)
-			if len(mounts) > 0 {
-				for _, mount := range mounts {
-					ch <- prometheus.MustNewConstMetric(
-						mountSuccessful, prometheus.GaugeValue, float64(0), mount.volume, mount.mountPoint,
-					)
-				}
+			log.Errorf("probe of mount %v failed: %v", mount.mountPoint, err)
+		}
+		for _, label := range mountStates {
+			value := 0.0
+			if label == state {
+				value = 1.0
+			}
+			ch <- prometheus.MustNewConstMetric(
+				mountState, prometheus.GaugeValue, value, mount.volume, mount.mountPoint, label,
+			)
+		}
+		if state == mountStateOK {
+			ch <- prometheus.MustNewConstMetric(
+				volumeWriteable, prometheus.GaugeValue, float64(1), mount.source, mount.mountPoint,
+			)
+		} else {
+			ch <- prometheus.MustNewConstMetric(
+				volumeWriteable, prometheus.GaugeValue, float64(0), mount.source, mount.mountPoint,
+			)
+		}
+
+		if len(e.ioStatsDir) > 0 && state == mountStateOK {
+			dump := &dumps[i]
+			dumpProbes[mount.mountPoint] = func(mountPoint string) error {
+				var err error
+				*dump, err = dumpIOStats(mountPoint, e.ioStatsDir)
+				return err
 			}
+		}
+	}
+	dumpResults := e.mountProber.probeAll(dumpProbes)
+	for i, mount := range mounts {
+		result, ok := dumpResults[mount.mountPoint]
+		if !ok {
+			continue
+		}
+		if result.err != nil {
+			log.Errorf("couldn't dump io-stats of mount %v: %v", mount.mountPoint, result.err)
+			continue
+		}
+		dump := dumps[i]
+
+		ch <- prometheus.MustNewConstMetric(
+			mountDataRead, prometheus.CounterValue, float64(dump.bytesRead), mount.volume, mount.mountPoint,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			mountDataWritten, prometheus.CounterValue, float64(dump.bytesWritten), mount.volume, mount.mountPoint,
+		)
+
+		for _, fop := range dump.fops {
+			ch <- prometheus.MustNewConstMetric(
+				mountFopHits, prometheus.CounterValue, float64(fop.hits), mount.volume, mount.mountPoint, fop.name,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				mountFopLatencyAvg, prometheus.GaugeValue, fop.avgLatency, mount.volume, mount.mountPoint, fop.name,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				mountFopLatencyMin, prometheus.GaugeValue, fop.minLatency, mount.volume, mount.mountPoint, fop.name,
+			)
+
+			ch <- prometheus.MustNewConstMetric(
+				mountFopLatencyMax, prometheus.GaugeValue, fop.maxLatency, mount.volume, mount.mountPoint, fop.name,
+			)
+		}
+	}
+	if len(e.fstab) > 0 {
+		fstabEntries := make(map[string]fstabEntry)
+		entries, err := readFstab(e.fstab)
+		if err != nil {
+			log.Errorf("couldn't read fstab: %v", err)
+		}
+		for _, entry := range entries {
+			fstabEntries[entry.mountPoint] = entry
+		}
+		fuseClients := make(map[string]glusterProcess)
+		fs, err := procfs.NewFS(procfs.DefaultMountPoint)
+		if err != nil {
+			log.Errorf("couldn't open procfs: %v", err)
 		} else {
-			for _, mount := range mounts {
+			processes, err := findGlusterfsProcesses(fs)
+			if err != nil {
+				log.Errorf("couldn't list glusterfs processes: %v", err)
+			}
+			for _, process := range processes {
+				if process.role == processRoleFuseClient {
+					fuseClients[process.path] = process
+				}
+			}
+		}
+		volumes := make(map[string]bool)
+		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+			volumes[volume.Name] = true
+		}
+
+		for _, mount := range mounts {
+			ch <- prometheus.MustNewConstMetric(
+				mountVolfileServer, prometheus.GaugeValue, 1.0, mount.volume, mount.mountPoint, mount.server,
+			)
+
+			if client, ok := fuseClients[mount.mountPoint]; ok && len(client.servers) > 0 {
 				ch <- prometheus.MustNewConstMetric(
-					mountSuccessful, prometheus.GaugeValue, float64(1), mount.volume, mount.mountPoint,
+					mountBackupVolfileServers, prometheus.GaugeValue, float64(len(client.servers)-1), mount.volume, mount.mountPoint,
 				)
+			}
 
-				isWriteable, err := execTouchOnVolumes(mount.mountPoint)
-				if err != nil {
-					log.Error(err)
-				}
-				if isWriteable {
-					ch <- prometheus.MustNewConstMetric(
-						volumeWriteable, prometheus.GaugeValue, float64(1), mount.volume, mount.mountPoint,
-					)
-				} else {
-					ch <- prometheus.MustNewConstMetric(
-						volumeWriteable, prometheus.GaugeValue, float64(0), mount.volume, mount.mountPoint,
-					)
+			entry, inFstab := fstabEntries[mount.mountPoint]
+			configured := 0.0
+			if inFstab {
+				configured = 1.0
+				ch <- prometheus.MustNewConstMetric(
+					mountFstabBackupVolfileServers, prometheus.GaugeValue, float64(len(backupVolfileServers(entry.options))), mount.volume, mount.mountPoint,
+				)
+			}
+			ch <- prometheus.MustNewConstMetric(
+				mountInFstab, prometheus.GaugeValue, configured, mount.volume, mount.mountPoint,
+			)
+
+			// volume info is only available on gluster servers
+			if volumeInfoOK {
+				exists := 0.0
+				if volumes[mount.volume] {
+					exists = 1.0
 				}
+				ch <- prometheus.MustNewConstMetric(
+					mountVolumeExists, prometheus.GaugeValue, exists, mount.volume, mount.mountPoint,
+				)
 			}
 		}
 	}
+	if e.roundTrip != nil {
+		// keep the metrics of all mounts if the mounts couldn't be read
+		if mountsOK {
+			e.roundTrip.prune(mounted)
+		}
+		e.roundTrip.latency.Collect(ch)
+		e.roundTrip.failures.Collect(ch)
+	}
+	if e.metadata != nil {
+		if mountsOK {
+			e.metadata.prune(mounted)
+		}
+		e.metadata.latency.Collect(ch)
+		e.metadata.errors.Collect(ch)
+	}
 	if e.quota {
+		// quotas were exported as counters by earlier versions
+		quotaValueType := prometheus.GaugeValue
+		if e.quotaCounters {
+			quotaValueType = prometheus.CounterValue
+		}
 		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
 			if e.volumes[0] == allVolumes || ContainsVolume(e.volumes, volume.Name) {
 				volumeQuotaXML, err := ExecVolumeQuotaList(volume.Name)
 				if err != nil {
 					log.Error("Cannot create quota metrics if quotas are not enabled in your gluster server")
 				} else {
-					for _, limit := range volumeQuotaXML.VolQuota.QuotaLimits {
+					usages, dropped := e.quotaPaths.apply(dataQuotaUsages(volumeQuotaXML.VolQuota.QuotaLimits))
+					for _, usage := range usages {
 						ch <- prometheus.MustNewConstMetric(
 							quotaHardLimit,
-							prometheus.CounterValue,
-							float64(limit.HardLimit),
-							limit.Path,
+							quotaValueType,
+							float64(usage.hardLimit),
+							usage.path,
 							volume.Name,
 						)
 
 						ch <- prometheus.MustNewConstMetric(
 							quotaSoftLimit,
-							prometheus.CounterValue,
-							float64(limit.SoftLimitValue),
-							limit.Path,
+							quotaValueType,
+							float64(usage.softLimit),
+							usage.path,
 							volume.Name,
 						)
 						ch <- prometheus.MustNewConstMetric(
 							quotaUsed,
-							prometheus.CounterValue,
-							float64(limit.UsedSpace),
-							limit.Path,
+							quotaValueType,
+							float64(usage.used),
+							usage.path,
 							volume.Name,
 						)
 
 						ch <- prometheus.MustNewConstMetric(
 							quotaAvailable,
-							prometheus.CounterValue,
-							float64(limit.AvailSpace),
-							limit.Path,
+							quotaValueType,
+							float64(usage.available),
+							usage.path,
 							volume.Name,
 						)
 
 						slExceeded := 0.0
-						if limit.SlExceeded != "No" {
+						if usage.slExceeded {
 							slExceeded = 1.0
 						}
 						ch <- prometheus.MustNewConstMetric(
 							quotaSoftLimitExceeded,
-							prometheus.CounterValue,
+							quotaValueType,
 							slExceeded,
-							limit.Path,
+							usage.path,
 							volume.Name,
 						)
 
 						hlExceeded := 0.0
-						if limit.HlExceeded != "No" {
+						if usage.hlExceeded {
 							hlExceeded = 1.0
 						}
 						ch <- prometheus.MustNewConstMetric(
 							quotaHardLimitExceeded,
-							prometheus.CounterValue,
+							quotaValueType,
 							hlExceeded,
-							limit.Path,
+							usage.path,
 							volume.Name,
 						)
+
+						if ratio, ok := quotaUsageRatio(usage.used, usage.hardLimit); ok {
+							ch <- prometheus.MustNewConstMetric(
+								quotaUsage, prometheus.GaugeValue, ratio, usage.path, volume.Name,
+							)
+						}
+					}
+					if e.quotaPaths.maxPaths > 0 {
+						ch <- prometheus.MustNewConstMetric(
+							quotaPathsDropped, prometheus.GaugeValue, float64(dropped), volume.Name, quotaKindData,
+						)
 					}
 				}
-			}
-		}
-	}
-}
 
-type mount struct {
-	mountPoint string
-	volume     string
-}
+				volumeQuotaObjectsXML, err := ExecVolumeQuotaListObjects(volume.Name)
+				if err != nil {
+					log.Errorf("couldn't parse xml of quota list-objects: %v", err)
+					continue
+				}
+				usages, dropped := e.quotaPaths.apply(objectQuotaUsages(volumeQuotaObjectsXML.VolQuota.QuotaLimits))
+				for _, usage := range usages {
+					ch <- prometheus.MustNewConstMetric(
+						quotaObjectsHardLimit, prometheus.GaugeValue, float64(usage.hardLimit), usage.path, volume.Name,
+					)
 
-// ParseMountOutput pares output of system execution 'mount'
-func parseMountOutput(mountBuffer string) ([]mount, error) {
-	mounts := make([]mount, 0, 2)
-	mountRows := strings.Split(mountBuffer, "\n")
-	for _, row := range mountRows {
-		trimmedRow := strings.TrimSpace(row)
-		if len(row) > 3 {
-			mountColumns := strings.Split(trimmedRow, " ")
-			mounts = append(mounts, mount{mountPoint: mountColumns[2], volume: mountColumns[0]})
-		}
-	}
-	return mounts, nil
+					ch <- prometheus.MustNewConstMetric(
+						quotaObjectsSoftLimit, prometheus.GaugeValue, float64(usage.softLimit), usage.path, volume.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						quotaFiles, prometheus.GaugeValue, float64(usage.files), usage.path, volume.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						quotaDirs, prometheus.GaugeValue, float64(usage.dirs), usage.path, volume.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						quotaObjectsAvailable, prometheus.GaugeValue, float64(usage.available), usage.path, volume.Name,
+					)
+
+					if ratio, ok := quotaUsageRatio(usage.used, usage.hardLimit); ok {
+						ch <- prometheus.MustNewConstMetric(
+							quotaObjectsUsage, prometheus.GaugeValue, ratio, usage.path, volume.Name,
+						)
+					}
+				}
+				if e.quotaPaths.maxPaths > 0 {
+					ch <- prometheus.MustNewConstMetric(
+						quotaPathsDropped, prometheus.GaugeValue, float64(dropped), volume.Name, quotaKindObjects,
+					)
+				}
+			}
+		}
+	}
+	if e.georep {
+		geoRepStatus, err := ExecVolumeGeoRepStatusDetail()
+		if err != nil {
+			log.Errorf("couldn't parse xml of geo-replication status: %v", err)
+		}
+		for _, volume := range geoRepStatus.GeoRep.Volumes {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volume.Name) {
+				continue
+			}
+			for _, session := range volume.Sessions {
+				for _, pair := range session.Pairs {
+					ch <- prometheus.MustNewConstMetric(
+						geoRepWorkerStatus, prometheus.GaugeValue, 1.0, volume.Name, pair.Slave, pair.MasterNode, pair.MasterBrick, pair.Status,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						geoRepCrawlStatus, prometheus.GaugeValue, 1.0, volume.Name, pair.Slave, pair.MasterNode, pair.MasterBrick, pair.CrawlStatus,
+					)
+
+					if lastSynced, ok := parseGlusterTime(pair.LastSynced); ok {
+						ch <- prometheus.MustNewConstMetric(
+							geoRepLastSynced, prometheus.GaugeValue, float64(lastSynced.Unix()), volume.Name, pair.Slave, pair.MasterNode, pair.MasterBrick,
+						)
+					}
+
+					// passive and faulty workers report N/A instead of counts
+					pending := map[*prometheus.Desc]string{
+						geoRepEntryPending: pair.Entry,
+						geoRepDataPending:  pair.Data,
+						geoRepMetaPending:  pair.Meta,
+						geoRepFailures:     pair.Failures,
+					}
+					for desc, value := range pending {
+						if count, ok := parseGeoRepCount(value); ok {
+							ch <- prometheus.MustNewConstMetric(
+								desc, prometheus.GaugeValue, count, volume.Name, pair.Slave, pair.MasterNode, pair.MasterBrick,
+							)
+						}
+					}
+				}
+			}
+		}
+	}
+	if e.snapshot {
+		snapInfo, err := ExecSnapshotInfo()
+		if err != nil {
+			log.Errorf("couldn't parse xml of snapshot info: %v", err)
+		}
+		snapshotCounts := make(map[string]int)
+		snapshotRemaining := make(map[string]int)
+		for _, snap := range snapInfo.Snapshots {
+			for _, snapVolume := range snap.SnapVolume {
+				volumeName := snapVolume.OriginVolume.Name
+				snapshotCounts[volumeName]++
+				snapshotRemaining[volumeName] = snapVolume.OriginVolume.SnapRemaining
+				if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volumeName) {
+					continue
+				}
+
+				if created, ok := parseGlusterTime(snap.CreateTime); ok {
+					ch <- prometheus.MustNewConstMetric(
+						snapshotCreated, prometheus.GaugeValue, float64(created.Unix()), volumeName, snap.Name,
+					)
+				}
+
+				activated := 0.0
+				if snapVolume.Status == "Started" {
+					activated = 1.0
+				}
+				ch <- prometheus.MustNewConstMetric(
+					snapshotActivated, prometheus.GaugeValue, activated, volumeName, snap.Name,
+				)
+			}
+		}
+
+		// without snapshot info every volume would report 0 snapshots
+		if err == nil {
+			for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+				if e.volumes[0] == allVolumes || ContainsVolume(e.volumes, volume.Name) {
+					ch <- prometheus.MustNewConstMetric(
+						volumeSnapshotCount, prometheus.GaugeValue, float64(snapshotCounts[volume.Name]), volume.Name,
+					)
+
+					if remaining, ok := snapshotRemaining[volume.Name]; ok {
+						ch <- prometheus.MustNewConstMetric(
+							volumeSnapshotRemaining, prometheus.GaugeValue, float64(remaining), volume.Name,
+						)
+					}
+				}
+			}
+		}
+
+		snapConfig, err := ExecSnapshotConfig()
+		if err != nil {
+			log.Errorf("couldn't parse xml of snapshot config: %v", err)
+		}
+		for _, volumeConfig := range snapConfig.VolumeConfig {
+			if e.volumes[0] == allVolumes || ContainsVolume(e.volumes, volumeConfig.Name) {
+				ch <- prometheus.MustNewConstMetric(
+					volumeSnapshotHardLimit, prometheus.GaugeValue, float64(volumeConfig.EffectiveHardLimit), volumeConfig.Name,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					volumeSnapshotSoftLimit, prometheus.GaugeValue, float64(volumeConfig.SoftLimit), volumeConfig.Name,
+				)
+			}
+		}
+	}
+	if len(e.options) > 0 || e.policy != nil {
+		for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, volume.Name) {
+				continue
+			}
+			volumeOptions, err := ExecVolumeGetAll(volume.Name)
+			if err != nil {
+				log.Errorf("couldn't parse xml of volume options: %v", err)
+				continue
+			}
+
+			if e.policy != nil {
+				actual := make(map[string]string, len(volumeOptions.Opt))
+				for _, opt := range volumeOptions.Opt {
+					actual[opt.Option] = opt.Value
+				}
+				violations := 0
+				for option, expected := range e.policy.ExpectedOptions(volume.Name, volume.TypeStr) {
+					compliant := 1.0
+					if value, ok := actual[option]; !ok || !optionCompliant(expected, value) {
+						compliant = 0.0
+						violations++
+					}
+					ch <- prometheus.MustNewConstMetric(
+						volumeOptionCompliant, prometheus.GaugeValue, compliant, volume.Name, option,
+					)
+				}
+				ch <- prometheus.MustNewConstMetric(
+					volumeOptionViolations, prometheus.GaugeValue, float64(violations), volume.Name,
+				)
+			}
+
+			for _, opt := range volumeOptions.Opt {
+				if !ContainsVolume(e.options, opt.Option) {
+					continue
+				}
+				value := trimVolumeOptionValue(opt.Value)
+				if number, err := strconv.ParseFloat(value, 64); err == nil {
+					ch <- prometheus.MustNewConstMetric(
+						volumeOptionValue, prometheus.GaugeValue, number, volume.Name, opt.Option,
+					)
+				} else {
+					ch <- prometheus.MustNewConstMetric(
+						volumeOptionInfo, prometheus.GaugeValue, 1.0, volume.Name, opt.Option, value,
+					)
+				}
+			}
+		}
+	}
+	if e.clients {
+		volumeStatusClients, err := ExecVolumeStatusAll("clients")
+		if err != nil {
+			log.Errorf("couldn't parse xml of volume status clients: %v", err)
+		}
+		for _, vol := range volumeStatusClients.VolStatus.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+				continue
+			}
+			for _, node := range vol.Node {
+				ch <- prometheus.MustNewConstMetric(
+					brickClientsConnected, prometheus.GaugeValue, float64(node.ClientsStatus.ClientCount), node.Hostname, node.Path, vol.VolName,
+				)
+
+				for _, client := range aggregateClients(node.ClientsStatus.Client) {
+					ch <- prometheus.MustNewConstMetric(
+						brickClientConnections, prometheus.GaugeValue, float64(client.connections), node.Hostname, node.Path, vol.VolName, client.host,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickClientDataRead, prometheus.GaugeValue, float64(client.bytesRead), node.Hostname, node.Path, vol.VolName, client.host,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickClientDataWritten, prometheus.GaugeValue, float64(client.bytesWrite), node.Hostname, node.Path, vol.VolName, client.host,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickClientOpVersion, prometheus.GaugeValue, float64(client.opVersion), node.Hostname, node.Path, vol.VolName, client.host,
+					)
+				}
+			}
+		}
+	}
+	if e.resources {
+		volumeStatusMem, err := ExecVolumeStatusAll("mem")
+		if err != nil {
+			log.Errorf("couldn't parse xml of volume status mem: %v", err)
+		}
+		for _, vol := range volumeStatusMem.VolStatus.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+				continue
+			}
+			for _, node := range vol.Node {
+				mallinfo := node.MemStatus.Mallinfo
+				ch <- prometheus.MustNewConstMetric(
+					brickMallinfoArena, prometheus.GaugeValue, float64(mallinfo.Arena), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickMallinfoMmap, prometheus.GaugeValue, float64(mallinfo.Hblkhd), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickMallinfoUsed, prometheus.GaugeValue, float64(mallinfo.Uordblks), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickMallinfoFree, prometheus.GaugeValue, float64(mallinfo.Fordblks), node.Hostname, node.Path, vol.VolName,
+				)
+
+				for _, pool := range node.MemStatus.Mempool.Pool {
+					ch <- prometheus.MustNewConstMetric(
+						brickMempoolHot, prometheus.GaugeValue, float64(pool.HotCount), node.Hostname, node.Path, vol.VolName, pool.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickMempoolCold, prometheus.GaugeValue, float64(pool.ColdCount), node.Hostname, node.Path, vol.VolName, pool.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickMempoolAllocs, prometheus.CounterValue, float64(pool.AllocCount), node.Hostname, node.Path, vol.VolName, pool.Name,
+					)
+
+					ch <- prometheus.MustNewConstMetric(
+						brickMempoolMisses, prometheus.CounterValue, float64(pool.PoolMisses), node.Hostname, node.Path, vol.VolName, pool.Name,
+					)
+				}
+			}
+		}
+
+		volumeStatusInode, err := ExecVolumeStatusAll("inode")
+		if err != nil {
+			log.Errorf("couldn't parse xml of volume status inode: %v", err)
+		}
+		for _, vol := range volumeStatusInode.VolStatus.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+				continue
+			}
+			for _, node := range vol.Node {
+				var active, lru, purge uint64
+				for _, connection := range node.InodeStatus.Connections.Connection {
+					active += connection.Itable.Active.Count
+					lru += connection.Itable.LRU.Count
+					purge += connection.Itable.Purge.Count
+				}
+				ch <- prometheus.MustNewConstMetric(
+					brickInodesActive, prometheus.GaugeValue, float64(active), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickInodesLRU, prometheus.GaugeValue, float64(lru), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickInodesPurge, prometheus.GaugeValue, float64(purge), node.Hostname, node.Path, vol.VolName,
+				)
+			}
+		}
+
+		volumeStatusFd, err := ExecVolumeStatusAll("fd")
+		if err != nil {
+			log.Errorf("couldn't parse xml of volume status fd: %v", err)
+		}
+		for _, vol := range volumeStatusFd.VolStatus.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+				continue
+			}
+			for _, node := range vol.Node {
+				fdsOpen := 0
+				for _, connection := range node.FdStatus.Connection {
+					fdsOpen += len(connection.FdTable.Fd)
+				}
+				ch <- prometheus.MustNewConstMetric(
+					brickFdConnections, prometheus.GaugeValue, float64(len(node.FdStatus.Connection)), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickFdsOpen, prometheus.GaugeValue, float64(fdsOpen), node.Hostname, node.Path, vol.VolName,
+				)
+			}
+		}
+	}
+	if e.callpool != nil {
+		volumeStatusCallPool, err := ExecVolumeStatusAll("callpool")
+		if err != nil {
+			log.Errorf("couldn't parse xml of volume status callpool: %v", err)
+		}
+		now := time.Now()
+		observed := make(map[string]bool)
+		for _, vol := range volumeStatusCallPool.VolStatus.Volumes.Volume {
+			if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+				continue
+			}
+			for _, node := range vol.Node {
+				frames := 0
+				uniques := make([]string, 0, len(node.CallPool.CallStack))
+				for _, callStack := range node.CallPool.CallStack {
+					frames += len(callStack.Frame)
+					uniques = append(uniques, callStack.Unique)
+				}
+				brick := node.Hostname + ":" + node.Path
+				observed[brick] = true
+				oldest := e.callpool.observe(brick, uniques, now)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickCallPoolCalls, prometheus.GaugeValue, float64(len(node.CallPool.CallStack)), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickCallPoolFrames, prometheus.GaugeValue, float64(frames), node.Hostname, node.Path, vol.VolName,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					brickCallPoolOldest, prometheus.GaugeValue, oldest.Seconds(), node.Hostname, node.Path, vol.VolName,
+				)
+			}
+		}
+		// keep the call stacks of all bricks if the scrape failed, their ages would restart otherwise
+		if err == nil {
+			e.callpool.prune(observed)
+		}
+	}
+	if e.statedump != nil {
+		if now := time.Now(); e.statedump.triggerDue(now) {
+			// only the bricks of this node, every node triggers its own
+			for _, process := range localBrickProcesses(volumeStatusAll, e.hostname) {
+				if e.volumes[0] == allVolumes || ContainsVolume(e.volumes, process.volume) {
+					if err := e.statedump.trigger(process.pid, now); err != nil {
+						log.Errorf("couldn't trigger statedump of brick %v: %v", process.path, err)
+					}
+				}
+			}
+		}
+
+		dumps, err := e.statedump.latest()
+		if err != nil {
+			log.Errorf("couldn't list statedumps: %v", err)
+		}
+		for _, latest := range dumps {
+			// the pid of the process changes with every restart
+			dump, process := latest.dump, latest.Name()
+			ch <- prometheus.MustNewConstMetric(
+				statedumpTimestamp, prometheus.GaugeValue, float64(latest.Time.Unix()), process,
+			)
+
+			for xlator, usage := range dump.MemoryUsage() {
+				ch <- prometheus.MustNewConstMetric(
+					statedumpXlatorMemory, prometheus.GaugeValue, float64(usage.Size), process, xlator,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					statedumpXlatorAllocs, prometheus.GaugeValue, float64(usage.NumAllocs), process, xlator,
+				)
+			}
+
+			for _, lock := range dump.Locks() {
+				ch <- prometheus.MustNewConstMetric(
+					statedumpLocks, prometheus.GaugeValue, float64(lock.Count), process, lock.Xlator, lock.Type, lock.State,
+				)
+			}
+
+			ch <- prometheus.MustNewConstMetric(
+				statedumpCallStacks, prometheus.GaugeValue, float64(dump.CallStacks()), process,
+			)
+		}
+	}
+	if e.processes {
+		processes := localBrickProcesses(volumeStatusAll, e.hostname)
+		glusterdPid, err := readPidfile(e.pidfile)
+		if err != nil {
+			log.Errorf("couldn't read glusterd pidfile: %v", err)
+		} else {
+			processes = append(processes, glusterProcess{pid: glusterdPid, role: processRoleGlusterd})
+		}
+		fs, err := procfs.NewFS(procfs.DefaultMountPoint)
+		if err != nil {
+			log.Errorf("couldn't open procfs: %v", err)
+		} else {
+			glusterfsProcesses, err := findGlusterfsProcesses(fs)
+			if err != nil {
+				log.Errorf("couldn't list glusterfs processes: %v", err)
+			}
+			processes = append(processes, glusterfsProcesses...)
+
+			for _, process := range processes {
+				if len(process.volume) > 0 && e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, process.volume) {
+					continue
+				}
+				stats, err := readProcessStats(fs, process.pid)
+				if err != nil {
+					log.Errorf("couldn't read stats of %v process %v: %v", process.role, process.pid, err)
+					continue
+				}
+
+				ch <- prometheus.MustNewConstMetric(
+					processCPUSeconds, prometheus.CounterValue, stats.cpuSeconds, process.role, process.volume, process.path,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					processResidentMemory, prometheus.GaugeValue, float64(stats.residentBytes), process.role, process.volume, process.path,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					processOpenFds, prometheus.GaugeValue, float64(stats.openFds), process.role, process.volume, process.path,
+				)
+
+				ch <- prometheus.MustNewConstMetric(
+					processThreads, prometheus.GaugeValue, float64(stats.threads), process.role, process.volume, process.path,
+				)
+			}
+		}
+	}
+}
+
+// trimVolumeOptionValue removes the "(DEFAULT)" marker newer gluster versions append to unchanged options
+func trimVolumeOptionValue(value string) string {
+	return strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "(DEFAULT)"))
+}
+
+// quotaUsageRatio returns the ratio of used to limit, limits of 0 have no ratio
+func quotaUsageRatio(used, limit uint64) (float64, bool) {
+	if limit == 0 {
+		return 0, false
+	}
+	return float64(used) / float64(limit), true
+}
+
+// glusterTimeLayout is the layout of timestamps in e.g. "gluster volume geo-replication status detail"
+// and "gluster snapshot info"
+const glusterTimeLayout = "2006-01-02 15:04:05"
+
+// parseGlusterTime parses a gluster timestamp, which is reported in local time of the node or as "N/A"
+func parseGlusterTime(value string) (time.Time, bool) {
+	t, err := time.ParseInLocation(glusterTimeLayout, strings.TrimSpace(value), time.Local)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t, true
+}
+
+// parseGeoRepCount parses a geo-replication counter, which is reported as number or as "N/A"
+func parseGeoRepCount(value string) (float64, bool) {
+	count, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return 0, false
+	}
+	return count, true
+}
+
+// collectThinPools exports the thin pools of the bricks of this node
+func (e *Exporter) collectThinPools(ch chan<- prometheus.Metric, volumeStatus structs.VolumeStatusXML) {
+	lvs, err := execLvs(e.lvs)
+	if err != nil {
+		log.Errorf("couldn't list logical volumes: %v", err)
+		return
+	}
+	pools := make(map[string]logicalVolume)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		if e.volumes[0] != allVolumes && !ContainsVolume(e.volumes, vol.VolName) {
+			continue
+		}
+		for _, node := range vol.Node {
+			if node.Status != 1 || len(node.Device) == 0 || !isLocalHostname(node.Hostname, e.hostname) {
+				continue
+			}
+			thin := 0.0
+			if lv, ok := findLogicalVolume(node.Device, lvs); ok {
+				if pool, ok := findThinPool(lv, lvs); ok {
+					thin = 1.0
+					pools[pool.vg+"/"+pool.name] = pool
+					ch <- prometheus.MustNewConstMetric(
+						brickThinPoolInfo, prometheus.GaugeValue, 1, node.Hostname, node.Path, vol.VolName, lv.vg, lv.name, pool.name,
+					)
+				}
+			}
+			ch <- prometheus.MustNewConstMetric(
+				brickThinProvisioned, prometheus.GaugeValue, thin, node.Hostname, node.Path, vol.VolName,
+			)
+		}
+	}
+	for _, pool := range pools {
+		ch <- prometheus.MustNewConstMetric(
+			thinPoolDataPercent, prometheus.GaugeValue, pool.dataPercent, e.hostname, pool.vg, pool.name,
+		)
+		ch <- prometheus.MustNewConstMetric(
+			thinPoolMetadataPercent, prometheus.GaugeValue, pool.metadataPercent, e.hostname, pool.vg, pool.name,
+		)
+	}
+}
+
+// collectMigrationStatus sends the per node progress of a rebalance or remove-brick task
+func collectMigrationStatus(ch chan<- prometheus.Metric, volumeName, task string, migration structs.VolRebalance) {
+	for _, node := range migration.Node {
+		ch <- prometheus.MustNewConstMetric(
+			migrationFilesScanned, prometheus.GaugeValue, float64(node.Lookups), volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationFilesMigrated, prometheus.GaugeValue, float64(node.Files), volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationDataBytes, prometheus.GaugeValue, float64(node.Size), volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationFailures, prometheus.GaugeValue, float64(node.Failures), volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationFilesSkipped, prometheus.GaugeValue, float64(node.Skipped), volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationRuntime, prometheus.GaugeValue, node.Runtime, volumeName, task, node.NodeName,
+		)
+
+		ch <- prometheus.MustNewConstMetric(
+			migrationStatus, prometheus.GaugeValue, float64(node.Status), volumeName, task, node.NodeName,
+		)
+	}
 }
 
 // ContainsVolume checks a slice if it contains an element
@@ -487,22 +2033,110 @@ func ContainsVolume(slice []string, element string) bool {
 	return false
 }
 
+// ExporterOptions configures NewExporter, empty paths and false flags disable the reports
+type ExporterOptions struct {
+	Hostname        string
+	GlusterExecPath string
+	// Volumes are comma separated volume names, "_all" for all volumes
+	Volumes       string
+	Profile       bool
+	Quota         bool
+	QuotaCounters bool
+	QuotaInclude  string
+	QuotaExclude  string
+	QuotaDepth    int
+	QuotaMaxPaths int
+	Georep        bool
+	Rebalance     bool
+	Snapshot      bool
+	// VolumeOptions are comma separated volume options to export
+	VolumeOptions     string
+	OptionPolicyPath  string
+	Clients           bool
+	Resources         bool
+	Callpool          bool
+	StatedumpDir      string
+	StatedumpInterval time.Duration
+	Processes         bool
+	GlusterdPidfile   string
+	MountProbeTimeout time.Duration
+	ProbeDir          string
+	RoundTrip         bool
+	RoundTripBytes    int
+	Metadata          bool
+	MetadataFiles     int
+	IOStatsDir        string
+	Fstab             string
+	LvsPath           string
+}
+
 // NewExporter initialises exporter
-func NewExporter(hostname, glusterExecPath, volumesString string, profile bool, quota bool) (*Exporter, error) {
-	if len(glusterExecPath) < 1 {
-		log.Fatalf("Gluster executable path is wrong: %v", glusterExecPath)
+func NewExporter(opts ExporterOptions) (*Exporter, error) {
+	if len(opts.GlusterExecPath) < 1 {
+		log.Fatalf("Gluster executable path is wrong: %v", opts.GlusterExecPath)
 	}
-	volumes := strings.Split(volumesString, ",")
+	volumes := strings.Split(opts.Volumes, ",")
 	if len(volumes) < 1 {
-		log.Warnf("No volumes given. Proceeding without volume information. Volumes: %v", volumesString)
+		log.Warnf("No volumes given. Proceeding without volume information. Volumes: %v", opts.Volumes)
+	}
+	var options []string
+	if len(opts.VolumeOptions) > 0 {
+		options = strings.Split(opts.VolumeOptions, ",")
+	}
+	var policy *OptionPolicyFile
+	if len(opts.OptionPolicyPath) > 0 {
+		var err error
+		policy, err = loadOptionPolicyFile(opts.OptionPolicyPath)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't load volume option policy %v: %v", opts.OptionPolicyPath, err)
+		}
+	}
+	quotaPaths, err := newQuotaPathFilter(opts.QuotaInclude, opts.QuotaExclude, opts.QuotaDepth, opts.QuotaMaxPaths)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't compile quota path expressions: %v", err)
+	}
+	var roundTripProber *roundTripProbe
+	if opts.RoundTrip {
+		roundTripProber = newRoundTripProbe(opts.Hostname, opts.ProbeDir, opts.RoundTripBytes)
+	}
+	var metadataProber *metadataProbe
+	if opts.Metadata {
+		metadataProber = newMetadataProbe(opts.Hostname, opts.ProbeDir, opts.MetadataFiles)
+	}
+	var callStacks *callStackTracker
+	if opts.Callpool {
+		callStacks = newCallStackTracker()
+	}
+	var statedumps *statedumpReader
+	if len(opts.StatedumpDir) > 0 {
+		statedumps = newStatedumpReader(opts.StatedumpDir, opts.StatedumpInterval)
 	}
 
 	return &Exporter{
-		hostname: hostname,
-		path:     glusterExecPath,
-		volumes:  volumes,
-		profile:  profile,
-		quota:    quota,
+		hostname:      opts.Hostname,
+		path:          opts.GlusterExecPath,
+		volumes:       volumes,
+		profile:       opts.Profile,
+		quota:         opts.Quota,
+		quotaCounters: opts.QuotaCounters,
+		quotaPaths:    quotaPaths,
+		georep:        opts.Georep,
+		rebalance:     opts.Rebalance,
+		snapshot:      opts.Snapshot,
+		options:       options,
+		policy:        policy,
+		clients:       opts.Clients,
+		resources:     opts.Resources,
+		callpool:      callStacks,
+		statedump:     statedumps,
+		processes:     opts.Processes,
+		pidfile:       opts.GlusterdPidfile,
+		mountProber:   newMountProber(opts.MountProbeTimeout),
+		roundTrip:     roundTripProber,
+		metadata:      metadataProber,
+		ioStatsDir:    opts.IOStatsDir,
+		fstab:         opts.Fstab,
+		lvs:           opts.LvsPath,
 	}, nil
 }
 
@@ -520,6 +2154,36 @@ func main() {
 		glusterVolumes = kingpin.Flag("gluster.volumes", fmt.Sprintf("Comma separated volume names: vol1,vol2,vol3. Default is '%v' to scrape all metrics", allVolumes)).Default(allVolumes).String()
 		profile        = kingpin.Flag("profile", "Enable gluster profiling reports.").Bool()
 		quota          = kingpin.Flag("quota", "Enable gluster quota reports.").Bool()
+		quotaCounters  = kingpin.Flag("quota.counters", "Export quota limits and usage as counters like earlier versions instead of gauges.").Bool()
+		quotaInclude   = kingpin.Flag("quota.path-include", "Regular expression of quota paths to export. Default is to export all paths").Default("").String()
+		quotaExclude   = kingpin.Flag("quota.path-exclude", "Regular expression of quota paths not to export.").Default("").String()
+		quotaDepth     = kingpin.Flag("quota.path-depth", "Aggregate quotas of deeper paths to their directory at this depth, e.g. 1 for /users. Default is to not aggregate").Default("0").Int()
+		quotaMaxPaths  = kingpin.Flag("quota.max-paths", "Maximum number of quota paths to export per volume, the paths closest to their hard limit are kept. Default is no limit").Default("0").Int()
+		georep         = kingpin.Flag("georep", "Enable gluster geo-replication reports.").Bool()
+		rebalance      = kingpin.Flag("rebalance", "Enable gluster rebalance and remove-brick reports.").Bool()
+		snapshot       = kingpin.Flag("snapshot", "Enable gluster snapshot reports.").Bool()
+		volumeOptions  = kingpin.Flag("gluster.volume-options", "Comma separated volume options to export from 'gluster volume get VOLNAME all': cluster.quorum-type,performance.cache-size. Default is to export no options").Default("").String()
+		clients        = kingpin.Flag("clients", "Enable gluster client connection reports.").Bool()
+		resources      = kingpin.Flag("brick-resources", "Enable gluster brick memory, inode table and fd table reports.").Bool()
+		callpool       = kingpin.Flag("callpool", "Enable gluster brick call pool reports.").Bool()
+		statedumps     = kingpin.Flag("statedump", "Enable reports of gluster statedumps.").Bool()
+		statedumpDir   = kingpin.Flag("statedump.dir", "Directory gluster writes statedumps to.").Default(statedump.DefaultDir).String()
+		statedumpEvery = kingpin.Flag("statedump.interval", "Interval to trigger statedumps of the local bricks with SIGUSR1, which needs root or the user of the brick processes. Superseded statedumps triggered by the exporter are removed. Default is to only read existing statedumps").Default("0s").Duration()
+		processes      = kingpin.Flag("process", "Enable reports of gluster process resource usage.").Bool()
+		glusterdPid    = kingpin.Flag("glusterd.pidfile", "Path to the pidfile of glusterd.").Default(DefaultGlusterdPidfile).String()
+		mountTimeout   = kingpin.Flag("mount.probe-timeout", "Time a probe of a gluster mount may take before the mount is reported as hung.").Default(DefaultMountProbeTimeout.String()).Duration()
+		probeDir       = kingpin.Flag("mount.probe-dir", "Directory below each gluster mount the probes work in.").Default(DefaultProbeDir).String()
+		roundTrip      = kingpin.Flag("mount.roundtrip", "Enable the read/write round trip probe of gluster mounts.").Bool()
+		roundTripBytes = kingpin.Flag("mount.roundtrip-bytes", "Number of bytes the round trip probe writes and reads back.").Default("4096").Int()
+		metadata       = kingpin.Flag("mount.metadata", "Enable the metadata probe of gluster mounts.").Bool()
+		metadataFiles  = kingpin.Flag("mount.metadata-files", "Number of files the metadata probe creates, renames and removes.").Default("20").Int()
+		ioStats        = kingpin.Flag("mount.io-stats", "Enable io-stats reports of the fuse clients of gluster mounts.").Bool()
+		ioStatsDir     = kingpin.Flag("mount.io-stats-dir", "Directory gluster writes io-stats dumps to.").Default(statedump.DefaultDir).String()
+		mountAudit     = kingpin.Flag("mount.audit", "Enable reports of the configuration of gluster mounts.").Bool()
+		fstab          = kingpin.Flag("mount.fstab", "Path to the file system table gluster mounts are configured in.").Default(DefaultFstab).String()
+		lvm            = kingpin.Flag("lvm", "Enable reports of the thin pools of the bricks of this node.").Bool()
+		lvsPath        = kingpin.Flag("lvm.lvs-path", "Path to the lvs executable.").Default(DefaultLvsPath).String()
+		optionPolicy   = kingpin.Flag("gluster.volume-option-policy", "Path to a yaml file with expected volume options per volume pattern.").Default("").String()
 		num            int
 	)
 
@@ -535,9 +2199,50 @@ func main() {
 	if err != nil {
 		log.Fatalf("While trying to get Hostname error happened: %v", err)
 	}
-	exporter, err := NewExporter(hostname, *glusterPath, *glusterVolumes, *profile, *quota)
+	opts := ExporterOptions{
+		Hostname:          hostname,
+		GlusterExecPath:   *glusterPath,
+		Volumes:           *glusterVolumes,
+		Profile:           *profile,
+		Quota:             *quota,
+		QuotaCounters:     *quotaCounters,
+		QuotaInclude:      *quotaInclude,
+		QuotaExclude:      *quotaExclude,
+		QuotaDepth:        *quotaDepth,
+		QuotaMaxPaths:     *quotaMaxPaths,
+		Georep:            *georep,
+		Rebalance:         *rebalance,
+		Snapshot:          *snapshot,
+		VolumeOptions:     *volumeOptions,
+		OptionPolicyPath:  *optionPolicy,
+		Clients:           *clients,
+		Resources:         *resources,
+		Callpool:          *callpool,
+		StatedumpInterval: *statedumpEvery,
+		Processes:         *processes,
+		GlusterdPidfile:   *glusterdPid,
+		MountProbeTimeout: *mountTimeout,
+		ProbeDir:          *probeDir,
+		RoundTrip:         *roundTrip,
+		RoundTripBytes:    *roundTripBytes,
+		Metadata:          *metadata,
+		MetadataFiles:     *metadataFiles,
+	}
+	if *statedumps {
+		opts.StatedumpDir = *statedumpDir
+	}
+	if *ioStats {
+		opts.IOStatsDir = *ioStatsDir
+	}
+	if *mountAudit {
+		opts.Fstab = *fstab
+	}
+	if *lvm {
+		opts.LvsPath = *lvsPath
+	}
+	exporter, err := NewExporter(opts)
 	if err != nil {
-		log.Errorf("Creating new Exporter went wrong, ... \n%v", err)
+		log.Fatalf("Creating new Exporter went wrong, ... \n%v", err)
 	}
 	prometheus.MustRegister(exporter)
 
@@ -10,35 +10,51 @@ func TestContainsVolume(t *testing.T) {
 	}
 }
 
-type testCases struct {
-	mountOutput string
-	expected    []string
+func TestParseGlusterTime(t *testing.T) {
+	lastSynced, ok := parseGlusterTime("2017-05-10 12:34:56")
+	if !ok {
+		t.Fatal("expected timestamp to be parsed")
+	}
+	if lastSynced.Year() != 2017 || lastSynced.Second() != 56 {
+		t.Errorf("timestamp was parsed to %v", lastSynced)
+	}
+
+	if _, ok := parseGlusterTime("N/A"); ok {
+		t.Error("expected N/A not to be parsed")
+	}
 }
 
-func TestParseMountOutput(t *testing.T) {
-	var tests = []testCases{
-		{
-			mountOutput: "/dev/mapper/cryptroot on / type ext4 (rw,relatime,data=ordered) \n" +
-				"/dev/mapper/cryptroot on /var/lib/docker/devicemapper type ext4 (rw,relatime,data=ordered)",
-			expected: []string{"/", "/var/lib/docker/devicemapper"},
-		},
-		{
-			mountOutput: "/dev/mapper/cryptroot on / type ext4 (rw,relatime,data=ordered) \n" +
-				"",
-			expected: []string{"/"},
-		},
-	}
-	for _, c := range tests {
-		mounts, err := parseMountOutput(c.mountOutput)
-		if err != nil {
-			t.Error(err)
-		}
+func TestParseGeoRepCount(t *testing.T) {
+	count, ok := parseGeoRepCount("12")
+	if !ok || count != 12 {
+		t.Errorf("expected 12 and got %v", count)
+	}
+
+	if _, ok := parseGeoRepCount("N/A"); ok {
+		t.Error("expected N/A not to be parsed")
+	}
+}
 
-		for i, mount := range mounts {
-			if mount.mountPoint != c.expected[i] {
-				t.Errorf("mountpoint is %v and %v was expected", mount.mountPoint, c.expected[i])
-			}
+func TestTrimVolumeOptionValue(t *testing.T) {
+	var tests = map[string]string{
+		"auto":         "auto",
+		"on (DEFAULT)": "on",
+		" 42 ":         "42",
+	}
+	for value, expected := range tests {
+		if trimmed := trimVolumeOptionValue(value); trimmed != expected {
+			t.Errorf("value %q was trimmed to %q and %q was expected", value, trimmed, expected)
 		}
 	}
+}
 
+func TestQuotaUsageRatio(t *testing.T) {
+	ratio, ok := quotaUsageRatio(335544320, 2147483648)
+	if !ok || ratio != 0.15625 {
+		t.Errorf("Expected ratio 0.15625, got %v (%v)", ratio, ok)
+	}
+
+	if _, ok := quotaUsageRatio(843, 0); ok {
+		t.Error("Expected no ratio without limit")
+	}
 }
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// operations of the metadata probe
+	metadataOpMkdir   = "mkdir"
+	metadataOpCreate  = "create"
+	metadataOpReaddir = "readdir"
+	metadataOpRename  = "rename"
+	metadataOpUnlink  = "unlink"
+	metadataOpRmdir   = "rmdir"
+)
+
+// metadataOps are all operations of the metadata probe
+var metadataOps = []string{
+	metadataOpMkdir,
+	metadataOpCreate,
+	metadataOpReaddir,
+	metadataOpRename,
+	metadataOpUnlink,
+	metadataOpRmdir,
+}
+
+// metadataProbe runs a burst of metadata operations in a scratch directory of a mount: mkdir, create
+// files, readdir, rename and unlink the files and rmdir. The latency of each operation is observed in
+// a histogram per mount.
+type metadataProbe struct {
+	hostname string
+	dir      string
+	files    int
+
+	latency *prometheus.HistogramVec
+	errors  *prometheus.CounterVec
+
+	mu sync.Mutex
+	// probed are the mounts with label values in latency and errors
+	probed map[probedMount]bool
+}
+
+func newMetadataProbe(hostname, dir string, files int) *metadataProbe {
+	return &metadataProbe{
+		hostname: hostname,
+		dir:      dir,
+		files:    files,
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mount_metadata_duration_seconds",
+			Help:      "Duration of the operations of the metadata probe of a mount",
+			Buckets:   prometheus.ExponentialBuckets(0.0005, 2, 14),
+		}, []string{"volume", "mountpoint", "operation"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mount_metadata_errors_total",
+			Help:      "Number of failed operations of the metadata probe of a mount",
+		}, []string{"volume", "mountpoint", "operation"}),
+		probed: make(map[probedMount]bool),
+	}
+}
+
+// run probes a mount and returns the unwrapped error of the first failed operation
+func (p *metadataProbe) run(volume, mountPoint string) error {
+	p.mu.Lock()
+	p.probed[probedMount{volume: volume, mountPoint: mountPoint}] = true
+	p.mu.Unlock()
+
+	base := filepath.Join(mountPoint, p.dir)
+	scratch := filepath.Join(base, fmt.Sprintf("metadata.%v.%v", p.hostname, time.Now().UnixNano()))
+	err := p.observe(volume, mountPoint, metadataOpMkdir, func() error {
+		if err := makeProbeDir(base); err != nil {
+			return err
+		}
+		return os.Mkdir(scratch, 0755)
+	})
+	if err != nil {
+		return err
+	}
+	// leaves nothing behind if an operation fails
+	defer os.RemoveAll(scratch)
+
+	names := make([]string, p.files)
+	for i := range names {
+		names[i] = filepath.Join(scratch, fmt.Sprintf("file%v", i))
+		err := p.observe(volume, mountPoint, metadataOpCreate, func() error {
+			file, err := os.OpenFile(names[i], os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+			if err != nil {
+				return err
+			}
+			return file.Close()
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	err = p.observe(volume, mountPoint, metadataOpReaddir, func() error {
+		dir, err := os.Open(scratch)
+		if err != nil {
+			return err
+		}
+		defer dir.Close()
+		entries, err := dir.Readdirnames(-1)
+		if err != nil {
+			return err
+		}
+		if len(entries) != len(names) {
+			return fmt.Errorf("readdir of %v returned %v entries instead of %v", scratch, len(entries), len(names))
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, name := range names {
+		renamed := name + ".renamed"
+		if err := p.observe(volume, mountPoint, metadataOpRename, func() error { return os.Rename(name, renamed) }); err != nil {
+			return err
+		}
+		names[i] = renamed
+	}
+
+	for _, name := range names {
+		if err := p.observe(volume, mountPoint, metadataOpUnlink, func() error { return os.Remove(name) }); err != nil {
+			return err
+		}
+	}
+
+	return p.observe(volume, mountPoint, metadataOpRmdir, func() error { return os.Remove(scratch) })
+}
+
+// observe runs an operation and records its latency or error
+func (p *metadataProbe) observe(volume, mountPoint, operation string, op func() error) error {
+	start := time.Now()
+	if err := op(); err != nil {
+		p.errors.WithLabelValues(volume, mountPoint, operation).Inc()
+		return err
+	}
+	p.latency.WithLabelValues(volume, mountPoint, operation).Observe(time.Since(start).Seconds())
+	return nil
+}
+
+// prune deletes the metrics of mounts which aren't mounted anymore
+func (p *metadataProbe) prune(mounted map[probedMount]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for mount := range p.probed {
+		if mounted[mount] {
+			continue
+		}
+		for _, operation := range metadataOps {
+			p.latency.DeleteLabelValues(mount.volume, mount.mountPoint, operation)
+			p.errors.DeleteLabelValues(mount.volume, mount.mountPoint, operation)
+		}
+		delete(p.probed, mount)
+	}
+}
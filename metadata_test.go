@@ -0,0 +1,81 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestMetadataProbe(t *testing.T) {
+	mountPoint, err := ioutil.TempDir("", "gluster_exporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mountPoint)
+
+	probe := newMetadataProbe("node1", DefaultProbeDir, 5)
+	if err := probe.run("gv_test", mountPoint); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(mountPoint, DefaultProbeDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected scratch directory to be removed, found %v files", len(files))
+	}
+
+	expected := map[string]uint64{
+		metadataOpMkdir:   1,
+		metadataOpCreate:  5,
+		metadataOpReaddir: 1,
+		metadataOpRename:  5,
+		metadataOpUnlink:  5,
+		metadataOpRmdir:   1,
+	}
+	for operation, want := range expected {
+		var metric dto.Metric
+		if err := probe.latency.WithLabelValues("gv_test", mountPoint, operation).(prometheus.Metric).Write(&metric); err != nil {
+			t.Fatal(err)
+		}
+		if count := metric.GetHistogram().GetSampleCount(); count != want {
+			t.Errorf("Expected %v observations of %v, got %v", want, operation, count)
+		}
+	}
+}
+
+func TestMetadataProbeFailure(t *testing.T) {
+	probe := newMetadataProbe("node1", DefaultProbeDir, 5)
+	if err := probe.run("gv_test", "test/nonexistent"); err == nil {
+		t.Fatal("Expected probe of missing mount point to fail")
+	}
+
+	var metric dto.Metric
+	if err := probe.errors.WithLabelValues("gv_test", "test/nonexistent", metadataOpMkdir).Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	if errors := metric.GetCounter().GetValue(); errors != 1 {
+		t.Errorf("Expected 1 error of mkdir, got %v", errors)
+	}
+}
+
+func TestMetadataProbePrune(t *testing.T) {
+	probe := newMetadataProbe("node1", DefaultProbeDir, 5)
+	probe.run("gv_test", "test/nonexistent")
+	probe.run("gv_test2", "test/nonexistent2")
+
+	probe.prune(map[probedMount]bool{{volume: "gv_test", mountPoint: "test/nonexistent"}: true})
+	if count := collectedMetrics(probe.errors); count != 1 {
+		t.Errorf("Expected errors of the mounted volume only, got %v", count)
+	}
+
+	probe.prune(map[probedMount]bool{})
+	if count := collectedMetrics(probe.errors); count != 0 {
+		t.Errorf("Expected no errors after unmount, got %v", count)
+	}
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	// mountinfoPath lists the mounts of the exporter's mount namespace
+	mountinfoPath = "/proc/self/mountinfo"
+
+	// glusterFSType is the file system type of gluster fuse mounts
+	glusterFSType = "fuse.glusterfs"
+)
+
+// mount is a gluster fuse mount
+type mount struct {
+	mountPoint string
+	// source is the mounted "server:/volume" as listed by mount(8), the volume label of mount_successful
+	// and volume_writeable
+	source string
+	// server is the volfile server the volume was mounted from
+	server string
+	volume string
+	// subdir is the mounted directory of the volume, empty for the whole volume
+	subdir  string
+	options []string
+}
+
+// readGlusterMounts returns the gluster fuse mounts listed in a mountinfo file
+func readGlusterMounts(path string) ([]mount, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parseMountinfo(file)
+}
+
+// parseMountinfo parses the gluster fuse mounts of /proc/PID/mountinfo. Each line looks like
+//
+//	36 35 0:48 / /mnt/gv_test rw,relatime shared:29 - fuse.glusterfs node1:/gv_test rw,user_id=0,group_id=0
+//
+// with a variable number of optional fields before the separator "-".
+func parseMountinfo(r io.Reader) ([]mount, error) {
+	var mounts []mount
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		separator := -1
+		for i := 6; i < len(fields); i++ {
+			if fields[i] == "-" {
+				separator = i
+				break
+			}
+		}
+		if separator < 0 || len(fields) < separator+4 {
+			return mounts, fmt.Errorf("malformed mountinfo line: %q", scanner.Text())
+		}
+		if fields[separator+1] != glusterFSType {
+			continue
+		}
+		source := unescapeMountinfo(fields[separator+2])
+		server, volume, subdir := splitMountSource(source)
+		options := strings.Split(fields[5], ",")
+		options = append(options, strings.Split(fields[separator+3], ",")...)
+		mounts = append(mounts, mount{
+			mountPoint: unescapeMountinfo(fields[4]),
+			source:     source,
+			server:     server,
+			volume:     volume,
+			subdir:     subdir,
+			options:    options,
+		})
+	}
+	return mounts, scanner.Err()
+}
+
+// splitMountSource splits the source "server:/volume/subdir" of a gluster mount. The volume name can't contain
+// a colon, so the last colon separates it from the server, which may be an IPv6 address.
+func splitMountSource(source string) (string, string, string) {
+	i := strings.LastIndex(source, ":")
+	if i < 0 {
+		return "", strings.Trim(source, "/"), ""
+	}
+	path := strings.Trim(source[i+1:], "/")
+	volume, subdir := path, ""
+	if j := strings.Index(path, "/"); j >= 0 {
+		volume, subdir = path[:j], path[j:]
+	}
+	return source[:i], volume, subdir
+}
+
+// unescapeMountinfo decodes the octal escapes the kernel uses for spaces, tabs, newlines and backslashes
+func unescapeMountinfo(field string) string {
+	if !strings.Contains(field, `\`) {
+		return field
+	}
+	var b bytes.Buffer
+	for i := 0; i < len(field); i++ {
+		if field[i] == '\\' && i+4 <= len(field) {
+			if c, err := strconv.ParseUint(field[i+1:i+4], 8, 8); err == nil {
+				b.WriteByte(byte(c))
+				i += 3
+				continue
+			}
+		}
+		b.WriteByte(field[i])
+	}
+	return b.String()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadGlusterMounts(t *testing.T) {
+	mounts, err := readGlusterMounts("test/mountinfo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []mount{
+		{
+			mountPoint: "/mnt/gv_test",
+			source:     "node1.example.local:/gv_test",
+			server:     "node1.example.local",
+			volume:     "gv_test",
+			options:    []string{"rw", "relatime", "rw", "user_id=0", "group_id=0", "default_permissions", "allow_other", "max_read=131072"},
+		},
+		{
+			mountPoint: "/mnt/gluster data",
+			source:     "node2.example.local:gv_test2",
+			server:     "node2.example.local",
+			volume:     "gv_test2",
+			options:    []string{"rw", "nosuid", "nodev", "relatime", "ro", "user_id=0", "group_id=0", "default_permissions", "allow_other", "max_read=131072"},
+		},
+		{
+			mountPoint: "/srv/home",
+			source:     "[fd00::12]:/gv_home/users/alice",
+			server:     "[fd00::12]",
+			volume:     "gv_home",
+			subdir:     "/users/alice",
+			options:    []string{"rw", "relatime", "rw", "user_id=0", "group_id=0", "default_permissions", "allow_other", "max_read=131072"},
+		},
+	}
+	if !reflect.DeepEqual(mounts, want) {
+		t.Errorf("want: %+v, got: %+v", want, mounts)
+	}
+
+	if _, err := readGlusterMounts("test/nonexistent"); err == nil {
+		t.Error("Expected error for missing mountinfo")
+	}
+}
+
+func TestParseMountinfoMalformed(t *testing.T) {
+	tests := []string{
+		"98 24 0:48 / /mnt/gv_test",
+		"98 24 0:48 / /mnt/gv_test rw,relatime shared:52 fuse.glusterfs node1:/gv_test rw",
+		"98 24 0:48 / /mnt/gv_test rw,relatime - fuse.glusterfs",
+	}
+	for _, line := range tests {
+		if _, err := parseMountinfo(strings.NewReader(line)); err == nil {
+			t.Errorf("Expected error for line %q", line)
+		}
+	}
+}
+
+func TestUnescapeMountinfo(t *testing.T) {
+	tests := map[string]string{
+		`/mnt/gv_test`:          "/mnt/gv_test",
+		`/mnt/gluster\040data`:  "/mnt/gluster data",
+		`/mnt/a\011b\012c\134d`: "/mnt/a\tb\nc\\d",
+		`/mnt/trailing\04`:      `/mnt/trailing\04`,
+		`/mnt/not\999octal`:     `/mnt/not\999octal`,
+	}
+	for field, want := range tests {
+		if got := unescapeMountinfo(field); got != want {
+			t.Errorf("unescapeMountinfo(%q) == %q, want %q", field, got, want)
+		}
+	}
+}
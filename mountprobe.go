@@ -0,0 +1,171 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// states of a mount probe
+	mountStateOK               = "ok"
+	mountStateHung             = "hung"
+	mountStateNotConnected     = "not_connected"
+	mountStateReadOnly         = "read_only"
+	mountStatePermissionDenied = "permission_denied"
+	mountStateError            = "error"
+
+	// DefaultMountProbeTimeout is the time a mount probe may take before the mount is reported as hung
+	DefaultMountProbeTimeout = 5 * time.Second
+)
+
+// mountStates are all states of a mount probe, exported as labels of gluster_mount_state
+var mountStates = []string{
+	mountStateOK,
+	mountStateHung,
+	mountStateNotConnected,
+	mountStateReadOnly,
+	mountStatePermissionDenied,
+	mountStateError,
+}
+
+// mountProber runs probes of mounts in their own goroutine with a timeout, so a hung fuse mount
+// doesn't block the scrape. A mount stays hung as long as its last probe hasn't returned, no further
+// probes of it are started meanwhile to not pile up blocked goroutines.
+type mountProber struct {
+	timeout time.Duration
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+func newMountProber(timeout time.Duration) *mountProber {
+	return &mountProber{timeout: timeout, running: make(map[string]bool)}
+}
+
+// mountProbeResult is the state of a mount and the error of its probe
+type mountProbeResult struct {
+	state string
+	err   error
+}
+
+// probe runs probeFunc on a mount point and returns the state of the mount
+func (p *mountProber) probe(mountPoint string, probeFunc func(string) error) (string, error) {
+	result := p.probeAll(map[string]func(string) error{mountPoint: probeFunc})[mountPoint]
+	return result.state, result.err
+}
+
+// probeAll starts the probes of all mount points at once and waits for them until a single timeout, so
+// several hung mounts don't block the scrape for a timeout each
+func (p *mountProber) probeAll(probes map[string]func(string) error) map[string]mountProbeResult {
+	results := make(map[string]mountProbeResult, len(probes))
+	started := make(map[string]<-chan error, len(probes))
+	for mountPoint, probeFunc := range probes {
+		done, err := p.start(mountPoint, probeFunc)
+		if err != nil {
+			results[mountPoint] = mountProbeResult{state: mountStateHung, err: err}
+			continue
+		}
+		started[mountPoint] = done
+	}
+
+	timer := time.NewTimer(p.timeout)
+	defer timer.Stop()
+	timedOut := false
+	for mountPoint, done := range started {
+		if !timedOut {
+			select {
+			case err := <-done:
+				results[mountPoint] = mountProbeResult{state: classifyMountError(err), err: err}
+				continue
+			case <-timer.C:
+				timedOut = true
+			}
+		}
+		// probes finished by the timeout still count
+		select {
+		case err := <-done:
+			results[mountPoint] = mountProbeResult{state: classifyMountError(err), err: err}
+		default:
+			results[mountPoint] = mountProbeResult{
+				state: mountStateHung,
+				err:   fmt.Errorf("probe of %v didn't finish within %v", mountPoint, p.timeout),
+			}
+		}
+	}
+	return results
+}
+
+// start runs probeFunc on a mount point in its own goroutine, unless the previous probe of the mount is still blocked
+func (p *mountProber) start(mountPoint string, probeFunc func(string) error) (<-chan error, error) {
+	p.mu.Lock()
+	if p.running[mountPoint] {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("previous probe of %v is still blocked", mountPoint)
+	}
+	p.running[mountPoint] = true
+	p.mu.Unlock()
+
+	done := make(chan error, 1)
+	go func() {
+		err := probeFunc(mountPoint)
+		p.mu.Lock()
+		delete(p.running, mountPoint)
+		p.mu.Unlock()
+		done <- err
+	}()
+	return done, nil
+}
+
+// classifyMountError maps the error of a mount probe to a mount state
+func classifyMountError(err error) string {
+	if err == nil {
+		return mountStateOK
+	}
+	switch e := err.(type) {
+	case *os.PathError:
+		err = e.Err
+	case *os.LinkError:
+		err = e.Err
+	case *os.SyscallError:
+		err = e.Err
+	}
+	switch err {
+	case syscall.ENOTCONN:
+		return mountStateNotConnected
+	case syscall.EROFS:
+		return mountStateReadOnly
+	case syscall.EACCES, syscall.EPERM:
+		return mountStatePermissionDenied
+	}
+	return mountStateError
+}
+
+// touchMount checks that a mount point can be accessed and a file can be created and removed in it
+func touchMount(mountPoint string) error {
+	if _, err := os.Stat(mountPoint); err != nil {
+		return err
+	}
+	_, err := execTouchOnVolumes(mountPoint)
+	return err
+}
+
+// mountProbe returns the probe of a mount of volume, touchMount followed by the enabled round trip and metadata probes
+func (e *Exporter) mountProbe(volume string) func(string) error {
+	return func(mountPoint string) error {
+		if err := touchMount(mountPoint); err != nil {
+			return err
+		}
+		if e.roundTrip != nil {
+			if err := e.roundTrip.run(volume, mountPoint); err != nil {
+				return err
+			}
+		}
+		if e.metadata != nil {
+			return e.metadata.run(volume, mountPoint)
+		}
+		return nil
+	}
+}
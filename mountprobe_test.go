@@ -0,0 +1,110 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestMountProberHung(t *testing.T) {
+	prober := newMountProber(10 * time.Millisecond)
+	release := make(chan struct{})
+	var calls int32
+	blocking := func(string) error {
+		atomic.AddInt32(&calls, 1)
+		<-release
+		return nil
+	}
+
+	if state, err := prober.probe("/mnt/gv_test", blocking); state != mountStateHung || err == nil {
+		t.Errorf("Expected hung mount, got %v (%v)", state, err)
+	}
+	if state, _ := prober.probe("/mnt/gv_test", blocking); state != mountStateHung {
+		t.Errorf("Expected mount to stay hung, got %v", state)
+	}
+	if calls := atomic.LoadInt32(&calls); calls != 1 {
+		t.Errorf("Expected no probe while the previous one is blocked, got %v probes", calls)
+	}
+
+	close(release)
+	for i := 0; i < 100; i++ {
+		prober.mu.Lock()
+		running := prober.running["/mnt/gv_test"]
+		prober.mu.Unlock()
+		if !running {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if state, err := prober.probe("/mnt/gv_test", blocking); state != mountStateOK || err != nil {
+		t.Errorf("Expected mount to recover, got %v (%v)", state, err)
+	}
+}
+
+func TestMountProberProbeAll(t *testing.T) {
+	timeout := 50 * time.Millisecond
+	prober := newMountProber(timeout)
+	release := make(chan struct{})
+	defer close(release)
+	blocking := func(string) error {
+		<-release
+		return nil
+	}
+	probes := map[string]func(string) error{
+		"/mnt/gv_hung1": blocking,
+		"/mnt/gv_hung2": blocking,
+		"/mnt/gv_hung3": blocking,
+		"/mnt/gv_ok":    func(string) error { return nil },
+		"/mnt/gv_down":  func(string) error { return syscall.ENOTCONN },
+	}
+
+	start := time.Now()
+	results := prober.probeAll(probes)
+	if elapsed := time.Since(start); elapsed >= 3*timeout {
+		t.Errorf("Expected hung mounts to share one timeout, took %v", elapsed)
+	}
+	want := map[string]string{
+		"/mnt/gv_hung1": mountStateHung,
+		"/mnt/gv_hung2": mountStateHung,
+		"/mnt/gv_hung3": mountStateHung,
+		"/mnt/gv_ok":    mountStateOK,
+		"/mnt/gv_down":  mountStateNotConnected,
+	}
+	for mountPoint, state := range want {
+		if results[mountPoint].state != state {
+			t.Errorf("Expected %v to be %v, got %v (%v)", mountPoint, state, results[mountPoint].state, results[mountPoint].err)
+		}
+	}
+}
+
+func TestClassifyMountError(t *testing.T) {
+	tests := []struct {
+		err   error
+		state string
+	}{
+		{nil, mountStateOK},
+		{&os.PathError{Op: "stat", Path: "/mnt/gv_test", Err: syscall.ENOTCONN}, mountStateNotConnected},
+		{&os.PathError{Op: "open", Path: "/mnt/gv_test/x", Err: syscall.EROFS}, mountStateReadOnly},
+		{&os.PathError{Op: "open", Path: "/mnt/gv_test/x", Err: syscall.EACCES}, mountStatePermissionDenied},
+		{&os.SyscallError{Syscall: "unlink", Err: syscall.EPERM}, mountStatePermissionDenied},
+		{syscall.ENOTCONN, mountStateNotConnected},
+		{errors.New("something else"), mountStateError},
+	}
+	for _, c := range tests {
+		if state := classifyMountError(c.err); state != c.state {
+			t.Errorf("classifyMountError(%v) == %v, want %v", c.err, state, c.state)
+		}
+	}
+}
+
+func TestTouchMount(t *testing.T) {
+	if err := touchMount(os.TempDir()); err != nil {
+		t.Error(err)
+	}
+	if state := classifyMountError(touchMount("test/nonexistent")); state != mountStateError {
+		t.Errorf("Expected error state for missing mount point, got %v", state)
+	}
+}
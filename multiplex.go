@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+// brickProcessKey identifies a brick process in the cluster
+type brickProcessKey struct {
+	hostname string
+	pid      int
+}
+
+// countBrickProcesses returns the number of bricks each brick process of "gluster volume status" serves.
+// With cluster.brick-multiplex enabled several bricks of a node share one glusterfsd process.
+// Offline bricks without a pid and self-heal daemons are not counted.
+func countBrickProcesses(volumeStatus structs.VolumeStatusXML) map[brickProcessKey]int {
+	bricks := make(map[brickProcessKey]int)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Pid <= 0 {
+				continue
+			}
+			bricks[brickProcessKey{hostname: node.Hostname, pid: node.Pid}]++
+		}
+	}
+	return bricks
+}
+
+// multiplexedHosts returns for each host whether one of its brick processes serves more than one brick
+func multiplexedHosts(bricks map[brickProcessKey]int) map[string]bool {
+	hosts := make(map[string]bool)
+	for process, count := range bricks {
+		hosts[process.hostname] = hosts[process.hostname] || count > 1
+	}
+	return hosts
+}
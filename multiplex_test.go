@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+func TestCountBrickProcesses(t *testing.T) {
+	file, err := os.Open("test/gluster_volume_status_all_detail.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	volumeStatus, err := structs.VolumeStatusAllDetailXMLUnmarshall(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bricks := countBrickProcesses(volumeStatus)
+	want := map[brickProcessKey]int{
+		{hostname: "node1.example.local", pid: 1342}: 2,
+		{hostname: "node2.example.local", pid: 1303}: 2,
+		{hostname: "node3.example.local", pid: 1284}: 2,
+		{hostname: "node4.example.local", pid: 1312}: 2,
+	}
+	if !reflect.DeepEqual(bricks, want) {
+		t.Errorf("want: %v, got: %v", want, bricks)
+	}
+}
+
+func TestMultiplexedHosts(t *testing.T) {
+	bricks := map[brickProcessKey]int{
+		{hostname: "node1.example.local", pid: 1342}: 2,
+		{hostname: "node1.example.local", pid: 1350}: 1,
+		{hostname: "node2.example.local", pid: 1303}: 1,
+		{hostname: "node2.example.local", pid: 1304}: 1,
+	}
+	want := map[string]bool{
+		"node1.example.local": true,
+		"node2.example.local": false,
+	}
+	if hosts := multiplexedHosts(bricks); !reflect.DeepEqual(hosts, want) {
+		t.Errorf("want: %v, got: %v", want, hosts)
+	}
+}
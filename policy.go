@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v2"
+)
+
+// OptionPolicy holds the expected volume options for all volumes matching the volume and type patterns
+type OptionPolicy struct {
+	Name    string            `yaml:"name"`
+	Volume  string            `yaml:"volume"`
+	Type    string            `yaml:"type"`
+	Options map[string]string `yaml:"options"`
+
+	volumeRegexp *regexp.Regexp
+	typeRegexp   *regexp.Regexp
+}
+
+// OptionPolicyFile represents the yaml file given with --gluster.volume-option-policy
+type OptionPolicyFile struct {
+	Policies []*OptionPolicy `yaml:"policies"`
+}
+
+// loadOptionPolicyFile reads and validates the volume option policy file at path
+func loadOptionPolicyFile(path string) (*OptionPolicyFile, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseOptionPolicyFile(b)
+}
+
+// parseOptionPolicyFile unmarshalls a volume option policy and compiles the volume and type patterns
+func parseOptionPolicyFile(b []byte) (*OptionPolicyFile, error) {
+	policyFile := &OptionPolicyFile{}
+	if err := yaml.UnmarshalStrict(b, policyFile); err != nil {
+		return nil, err
+	}
+
+	for i, policy := range policyFile.Policies {
+		if len(policy.Options) < 1 {
+			return nil, fmt.Errorf("policy %d (%v) has no options", i, policy.Name)
+		}
+		var err error
+		if policy.volumeRegexp, err = compileAnchored(policy.Volume); err != nil {
+			return nil, fmt.Errorf("policy %d (%v) has invalid volume pattern: %v", i, policy.Name, err)
+		}
+		if policy.typeRegexp, err = compileAnchored(policy.Type); err != nil {
+			return nil, fmt.Errorf("policy %d (%v) has invalid type pattern: %v", i, policy.Name, err)
+		}
+	}
+	return policyFile, nil
+}
+
+// compileAnchored compiles pattern to match whole strings, an empty pattern matches everything
+func compileAnchored(pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		pattern = ".*"
+	}
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+// ExpectedOptions merges the options of all policies matching a volume, later policies win
+func (p *OptionPolicyFile) ExpectedOptions(volumeName, volumeType string) map[string]string {
+	expected := make(map[string]string)
+	for _, policy := range p.Policies {
+		if !policy.volumeRegexp.MatchString(volumeName) || !policy.typeRegexp.MatchString(volumeType) {
+			continue
+		}
+		for option, value := range policy.Options {
+			expected[option] = value
+		}
+	}
+	return expected
+}
+
+// optionCompliant compares an expected option value with the one reported by gluster
+func optionCompliant(expected, actual string) bool {
+	return strings.EqualFold(strings.TrimSpace(expected), trimVolumeOptionValue(actual))
+}
@@ -0,0 +1,60 @@
+package main
+
+import "testing"
+
+func TestLoadOptionPolicyFile(t *testing.T) {
+	policyFile, err := loadOptionPolicyFile("test/volume_option_policy.yml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(policyFile.Policies) != 3 {
+		t.Fatalf("Expected 3 policies and len is %v", len(policyFile.Policies))
+	}
+
+	expected := policyFile.ExpectedOptions("gv_test", "Replicate")
+	if len(expected) != 4 {
+		t.Errorf("Expected 4 options for gv_test and got %v", expected)
+	}
+	if expected["cluster.quorum-type"] != "auto" {
+		t.Errorf("Expected cluster.quorum-type auto for gv_test and got %v", expected["cluster.quorum-type"])
+	}
+
+	expected = policyFile.ExpectedOptions("gv_test2", "Distribute")
+	if len(expected) != 3 {
+		t.Errorf("Expected 3 options for gv_test2 and got %v", expected)
+	}
+	if expected["cluster.quorum-type"] != "fixed" {
+		t.Errorf("Expected cluster.quorum-type fixed for gv_test2 and got %v", expected["cluster.quorum-type"])
+	}
+
+	expected = policyFile.ExpectedOptions("gv_cluster", "Distribute")
+	if len(expected) != 0 {
+		t.Errorf("Expected no options for gv_cluster and got %v", expected)
+	}
+}
+
+func TestParseOptionPolicyFileErrors(t *testing.T) {
+	var tests = []string{
+		"policies:\n  - name: empty\n",
+		"policies:\n  - volume: \"gv_(\"\n    options:\n      nfs.disable: \"on\"\n",
+		"policy:\n  - options:\n      nfs.disable: \"on\"\n",
+	}
+	for _, c := range tests {
+		if _, err := parseOptionPolicyFile([]byte(c)); err == nil {
+			t.Errorf("Expected error for policy %q", c)
+		}
+	}
+}
+
+func TestOptionCompliant(t *testing.T) {
+	if !optionCompliant("on", "on (DEFAULT)") {
+		t.Error("Expected on to match on (DEFAULT)")
+	}
+	if !optionCompliant("auto", "AUTO") {
+		t.Error("Expected auto to match AUTO")
+	}
+	if optionCompliant("auto", "none") {
+		t.Error("Expected auto not to match none")
+	}
+}
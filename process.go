@@ -0,0 +1,192 @@
+package main
+
+import (
+	"io/ioutil"
+	"net"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+	"github.com/prometheus/procfs"
+)
+
+const (
+	// DefaultGlusterdPidfile is the pidfile glusterd writes when started by its init script or systemd unit
+	DefaultGlusterdPidfile = "/var/run/glusterd.pid"
+
+	// process roles
+	processRoleGlusterd   = "glusterd"
+	processRoleBrick      = "brick"
+	processRoleShd        = "shd"
+	processRoleFuseClient = "fuse"
+
+	// hostname "gluster volume status" reports for self-heal daemons
+	selfHealDaemonHostname = "Self-heal Daemon"
+)
+
+// glusterProcess is a running gluster process and the volume and path it serves
+type glusterProcess struct {
+	pid    int
+	role   string
+	volume string
+	path   string
+	// servers are the volfile servers of a fuse client, the first one and its backups
+	servers []string
+}
+
+// processStats holds the resource usage of a process read from /proc
+type processStats struct {
+	cpuSeconds    float64
+	residentBytes int
+	openFds       int
+	threads       int
+}
+
+// readPidfile returns the pid written to a pidfile
+func readPidfile(path string) (int, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(content)))
+}
+
+// parseVolfileID returns the value of the --volfile-id argument of a glusterfs command line
+func parseVolfileID(cmdline []string) (string, bool) {
+	for i, arg := range cmdline {
+		if strings.HasPrefix(arg, "--volfile-id=") {
+			return strings.TrimPrefix(arg, "--volfile-id="), true
+		}
+		if arg == "--volfile-id" && i+1 < len(cmdline) {
+			return cmdline[i+1], true
+		}
+	}
+	return "", false
+}
+
+// parseVolfileServers returns the values of all --volfile-server arguments of a glusterfs command line.
+// mount.glusterfs passes the backup-volfile-servers as additional --volfile-server arguments.
+func parseVolfileServers(cmdline []string) []string {
+	var servers []string
+	for i, arg := range cmdline {
+		if strings.HasPrefix(arg, "--volfile-server=") {
+			servers = append(servers, strings.TrimPrefix(arg, "--volfile-server="))
+		} else if (arg == "--volfile-server" || arg == "-s") && i+1 < len(cmdline) {
+			servers = append(servers, cmdline[i+1])
+		}
+	}
+	return servers
+}
+
+// classifyGlusterfs returns the role and volume of a glusterfs process by its volfile id.
+// Self-heal daemons use "gluster/glustershd" or "shd/VOLNAME", other daemons like nfs and
+// quotad use "gluster/NAME". Everything else is a fuse client mounting the volume.
+func classifyGlusterfs(volfileID string) (string, string, bool) {
+	volfileID = strings.TrimPrefix(volfileID, "/")
+	switch {
+	case volfileID == "gluster/glustershd":
+		return processRoleShd, "", true
+	case strings.HasPrefix(volfileID, "shd/"):
+		return processRoleShd, strings.TrimPrefix(volfileID, "shd/"), true
+	case strings.HasPrefix(volfileID, "gluster/"):
+		return "", "", false
+	}
+	return processRoleFuseClient, volfileID, true
+}
+
+// isLocalHostname reports whether a hostname of "gluster volume status" is the node the exporter runs on. Peers
+// may be probed by their short or fully qualified name, so only the names up to the first dot are compared.
+func isLocalHostname(nodeHostname, hostname string) bool {
+	return nodeHostname == "localhost" || nodeHostname == hostname || shortHostname(nodeHostname) == shortHostname(hostname)
+}
+
+// shortHostname returns a hostname up to the first dot, ip addresses are returned as is
+func shortHostname(hostname string) string {
+	if net.ParseIP(hostname) != nil {
+		return hostname
+	}
+	if i := strings.Index(hostname, "."); i >= 0 {
+		return hostname[:i]
+	}
+	return hostname
+}
+
+// localBrickProcesses returns the brick processes of this node from "gluster volume status".
+// With brick multiplexing a process serving several bricks is only returned once, labelled with
+// its first brick, so its usage isn't counted for every brick. gluster_brick_process_info maps
+// the other bricks to the process.
+func localBrickProcesses(volumeStatus structs.VolumeStatusXML, hostname string) []glusterProcess {
+	var processes []glusterProcess
+	seen := make(map[int]bool)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Pid <= 0 || seen[node.Pid] {
+				continue
+			}
+			if !isLocalHostname(node.Hostname, hostname) {
+				continue
+			}
+			seen[node.Pid] = true
+			processes = append(processes, glusterProcess{pid: node.Pid, role: processRoleBrick, volume: vol.VolName, path: node.Path})
+		}
+	}
+	return processes
+}
+
+// findGlusterfsProcesses scans procfs for self-heal daemons and fuse clients
+func findGlusterfsProcesses(fs procfs.FS) ([]glusterProcess, error) {
+	procs, err := fs.AllProcs()
+	if err != nil {
+		return nil, err
+	}
+	sort.Sort(procs)
+	var processes []glusterProcess
+	for _, proc := range procs {
+		comm, err := proc.Comm()
+		if err != nil || comm != "glusterfs" {
+			continue
+		}
+		cmdline, err := proc.CmdLine()
+		if err != nil {
+			continue
+		}
+		volfileID, ok := parseVolfileID(cmdline)
+		if !ok {
+			continue
+		}
+		role, volume, ok := classifyGlusterfs(volfileID)
+		if !ok {
+			continue
+		}
+		process := glusterProcess{pid: proc.PID, role: role, volume: volume}
+		if role == processRoleFuseClient {
+			process.path = cmdline[len(cmdline)-1]
+			process.servers = parseVolfileServers(cmdline)
+		}
+		processes = append(processes, process)
+	}
+	return processes, nil
+}
+
+// readProcessStats reads cpu time, memory, fds and threads of a process
+func readProcessStats(fs procfs.FS, pid int) (processStats, error) {
+	proc, err := fs.NewProc(pid)
+	if err != nil {
+		return processStats{}, err
+	}
+	stat, err := proc.NewStat()
+	if err != nil {
+		return processStats{}, err
+	}
+	fds, err := proc.FileDescriptorsLen()
+	if err != nil {
+		return processStats{}, err
+	}
+	return processStats{
+		cpuSeconds:    stat.CPUTime(),
+		residentBytes: stat.ResidentMemory(),
+		openFds:       fds,
+		threads:       stat.NumThreads,
+	}, nil
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+	"github.com/prometheus/procfs"
+)
+
+func TestReadPidfile(t *testing.T) {
+	pid, err := readPidfile("test/glusterd.pid")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := 1100; pid != want {
+		t.Errorf("want: %v, got: %v", want, pid)
+	}
+
+	if _, err := readPidfile("test/nonexistent.pid"); err == nil {
+		t.Error("Expected error for missing pidfile")
+	}
+}
+
+func TestParseVolfileID(t *testing.T) {
+	tests := []struct {
+		cmdline []string
+		id      string
+		ok      bool
+	}{
+		{[]string{"/usr/sbin/glusterfs", "--volfile-server=node1", "--volfile-id=/gv_test", "/mnt/gv_test"}, "/gv_test", true},
+		{[]string{"/usr/sbin/glusterfs", "-s", "localhost", "--volfile-id", "gluster/glustershd"}, "gluster/glustershd", true},
+		{[]string{"/usr/sbin/glusterfs", "--volfile-id"}, "", false},
+		{[]string{"/usr/sbin/glusterd", "-p", "/var/run/glusterd.pid"}, "", false},
+	}
+	for _, c := range tests {
+		id, ok := parseVolfileID(c.cmdline)
+		if id != c.id || ok != c.ok {
+			t.Errorf("parseVolfileID(%v) == (%q, %v), want (%q, %v)", c.cmdline, id, ok, c.id, c.ok)
+		}
+	}
+}
+
+func TestParseVolfileServers(t *testing.T) {
+	cmdline := []string{"/usr/sbin/glusterfs", "--volfile-server=node1", "--volfile-server", "node2", "-s", "node3", "--volfile-id=/gv_test", "/mnt/gv_test"}
+	want := []string{"node1", "node2", "node3"}
+	if servers := parseVolfileServers(cmdline); !reflect.DeepEqual(servers, want) {
+		t.Errorf("want: %v, got: %v", want, servers)
+	}
+}
+
+func TestIsLocalHostname(t *testing.T) {
+	tests := []struct {
+		nodeHostname string
+		hostname     string
+		local        bool
+	}{
+		{"node1", "node1", true},
+		{"localhost", "node1", true},
+		{"node1.example.com", "node1", true},
+		{"node1", "node1.example.com", true},
+		{"node10", "node1", false},
+		{"node10.example.com", "node1", false},
+		{"node1", "node10.example.com", false},
+		{"10.0.0.2", "10.0.0.1", false},
+	}
+	for _, c := range tests {
+		if local := isLocalHostname(c.nodeHostname, c.hostname); local != c.local {
+			t.Errorf("isLocalHostname(%q, %q) == %v, want %v", c.nodeHostname, c.hostname, local, c.local)
+		}
+	}
+}
+
+func TestLocalBrickProcesses(t *testing.T) {
+	file, err := os.Open("test/gluster_volume_status_all_detail.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	volumeStatus, err := structs.VolumeStatusAllDetailXMLUnmarshall(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processes := localBrickProcesses(volumeStatus, "node1")
+	want := []glusterProcess{
+		{pid: 1342, role: processRoleBrick, volume: "gv_test", path: "/mnt/gluster/gv_test"},
+	}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("want: %v, got: %v", want, processes)
+	}
+}
+
+func TestFindGlusterfsProcesses(t *testing.T) {
+	fs, err := procfs.NewFS("test/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	processes, err := findGlusterfsProcesses(fs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []glusterProcess{
+		{pid: 1420, role: processRoleShd},
+		{pid: 2311, role: processRoleFuseClient, volume: "gv_test", path: "/mnt/gv_test", servers: []string{"node1.example.local", "node2.example.local"}},
+	}
+	if !reflect.DeepEqual(processes, want) {
+		t.Errorf("want: %v, got: %v", want, processes)
+	}
+}
+
+func TestReadProcessStats(t *testing.T) {
+	fs, err := procfs.NewFS("test/proc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stats, err := readProcessStats(fs, 1342)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := processStats{
+		cpuSeconds:    20,
+		residentBytes: 5120 * os.Getpagesize(),
+		openFds:       18,
+		threads:       24,
+	}
+	if stats != want {
+		t.Errorf("want: %+v, got: %+v", want, stats)
+	}
+
+	if _, err := readProcessStats(fs, 9999); err == nil {
+		t.Error("Expected error for missing process")
+	}
+}
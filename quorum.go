@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+const (
+	// options of client quorum, enforced by the replicate xlator of the clients per replica set
+	quorumTypeOption  = "cluster.quorum-type"
+	quorumCountOption = "cluster.quorum-count"
+
+	// options of server quorum, enforced by glusterd which stops the bricks of a node without quorum
+	serverQuorumTypeOption  = "cluster.server-quorum-type"
+	serverQuorumRatioOption = "cluster.server-quorum-ratio"
+
+	// values of cluster.quorum-type
+	quorumTypeNone  = "none"
+	quorumTypeAuto  = "auto"
+	quorumTypeFixed = "fixed"
+
+	// value of cluster.server-quorum-type enabling server quorum
+	serverQuorumTypeServer = "server"
+
+	// state of "gluster peer status" of peers which are members of the trusted pool, "Peer in Cluster"
+	peerStateInCluster = 3
+)
+
+// clientQuorum is the client quorum of the replica sets of a volume
+type clientQuorum struct {
+	quorumType string
+	count      int
+}
+
+// volumeClientQuorum returns the client quorum of a replicated volume. Without cluster.quorum-type gluster
+// enforces auto quorum for sets of 3 bricks or more, including arbiter sets, and none for replica 2.
+func volumeClientQuorum(volume structs.Volume) (clientQuorum, error) {
+	quorumType, ok := volumeOption(volume, quorumTypeOption)
+	if !ok {
+		quorumType = quorumTypeNone
+		if volume.ReplicaCount >= 3 {
+			quorumType = quorumTypeAuto
+		}
+	}
+	switch quorumType {
+	case quorumTypeNone, quorumTypeAuto:
+		return clientQuorum{quorumType: quorumType}, nil
+	case quorumTypeFixed:
+		value, _ := volumeOption(volume, quorumCountOption)
+		count, err := strconv.Atoi(value)
+		if err != nil || count < 1 {
+			return clientQuorum{}, fmt.Errorf("invalid %v %q for fixed quorum", quorumCountOption, value)
+		}
+		return clientQuorum{quorumType: quorumType, count: count}, nil
+	}
+	return clientQuorum{}, fmt.Errorf("unknown %v %q", quorumTypeOption, quorumType)
+}
+
+// met reports whether a replica set has client quorum and accepts writes. Auto quorum needs more than half of the
+// bricks online, or exactly half including the first brick. Without quorum any online brick accepts writes.
+func (q clientQuorum) met(health subvolumeHealth) bool {
+	switch q.quorumType {
+	case quorumTypeFixed:
+		return health.up >= q.count
+	case quorumTypeAuto:
+		return 2*health.up > health.bricks || (2*health.up == health.bricks && health.firstUp)
+	}
+	return health.up > 0
+}
+
+// serverQuorumEnforced reports whether glusterd enforces server quorum for the bricks of a volume
+func serverQuorumEnforced(volume structs.Volume) bool {
+	quorumType, _ := volumeOption(volume, serverQuorumTypeOption)
+	return quorumType == serverQuorumTypeServer
+}
+
+// serverQuorumRatio returns cluster.server-quorum-ratio of "gluster volume get all cluster.server-quorum-ratio". It is
+// a global option set with "gluster volume set all" and not listed in the options of the volumes. The ratio is empty
+// if it isn't set.
+func serverQuorumRatio(opts structs.VolGetOpts) string {
+	for _, opt := range opts.Opt {
+		if opt.Option != serverQuorumRatioOption {
+			continue
+		}
+		if ratio := trimVolumeOptionValue(opt.Value); ratio != "(null)" {
+			return ratio
+		}
+	}
+	return ""
+}
+
+// hasServerQuorum reports whether the trusted pool has server quorum from the view of this node. Like glusterd only
+// peers in the "Peer in Cluster" state count, peers still being probed or rejected aren't members of the pool. This
+// node and its connected peers are active. Without cluster.server-quorum-ratio more than half of the nodes need to
+// be active, otherwise at least the ratio in percent.
+func hasServerQuorum(peers []structs.Peer, ratio string) (bool, error) {
+	total, active := 1, 1
+	for _, peer := range peers {
+		if peer.State != peerStateInCluster {
+			continue
+		}
+		total++
+		if peer.Connected == 1 {
+			active++
+		}
+	}
+	if len(ratio) == 0 {
+		return active >= total/2+1, nil
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(ratio), "%"), 64)
+	if err != nil || percent < 0 || percent > 100 {
+		return false, fmt.Errorf("invalid %v %q", serverQuorumRatioOption, ratio)
+	}
+	return float64(active) >= math.Ceil(float64(total)*percent/100), nil
+}
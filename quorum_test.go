@@ -0,0 +1,156 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+func TestVolumeClientQuorum(t *testing.T) {
+	option := func(name, value string) structs.VolumeOptions {
+		return structs.VolumeOptions{Option: []structs.VolumeOption{{Name: name, Value: value}}}
+	}
+	tests := []struct {
+		volume structs.Volume
+		want   clientQuorum
+		err    bool
+	}{
+		{structs.Volume{ReplicaCount: 2}, clientQuorum{quorumType: quorumTypeNone}, false},
+		{structs.Volume{ReplicaCount: 3}, clientQuorum{quorumType: quorumTypeAuto}, false},
+		{structs.Volume{ReplicaCount: 2, Options: option(quorumTypeOption, "auto")}, clientQuorum{quorumType: quorumTypeAuto}, false},
+		{structs.Volume{ReplicaCount: 3, Options: structs.VolumeOptions{Option: []structs.VolumeOption{
+			{Name: quorumTypeOption, Value: "fixed"},
+			{Name: quorumCountOption, Value: "1"},
+		}}}, clientQuorum{quorumType: quorumTypeFixed, count: 1}, false},
+		{structs.Volume{ReplicaCount: 3, Options: option(quorumTypeOption, "fixed")}, clientQuorum{}, true},
+		{structs.Volume{ReplicaCount: 3, Options: option(quorumTypeOption, "majority")}, clientQuorum{}, true},
+	}
+	for _, c := range tests {
+		got, err := volumeClientQuorum(c.volume)
+		if got != c.want || (err != nil) != c.err {
+			t.Errorf("volumeClientQuorum(%+v) == (%+v, %v), want %+v", c.volume.Options, got, err, c.want)
+		}
+	}
+}
+
+func TestClientQuorumMet(t *testing.T) {
+	tests := []struct {
+		quorum clientQuorum
+		health subvolumeHealth
+		want   bool
+	}{
+		{clientQuorum{quorumType: quorumTypeAuto}, subvolumeHealth{bricks: 3, up: 2}, true},
+		{clientQuorum{quorumType: quorumTypeAuto}, subvolumeHealth{bricks: 3, up: 1, firstUp: true}, false},
+		// half of the bricks is enough if the first brick is among them
+		{clientQuorum{quorumType: quorumTypeAuto}, subvolumeHealth{bricks: 2, up: 1, firstUp: true}, true},
+		{clientQuorum{quorumType: quorumTypeAuto}, subvolumeHealth{bricks: 2, up: 1}, false},
+		{clientQuorum{quorumType: quorumTypeFixed, count: 2}, subvolumeHealth{bricks: 3, up: 2}, true},
+		{clientQuorum{quorumType: quorumTypeFixed, count: 2}, subvolumeHealth{bricks: 3, up: 1}, false},
+		{clientQuorum{quorumType: quorumTypeNone}, subvolumeHealth{bricks: 2, up: 1}, true},
+		{clientQuorum{quorumType: quorumTypeNone}, subvolumeHealth{bricks: 2, up: 0}, false},
+	}
+	for _, c := range tests {
+		if got := c.quorum.met(c.health); got != c.want {
+			t.Errorf("%+v met(%+v) == %v, want %v", c.quorum, c.health, got, c.want)
+		}
+	}
+}
+
+func TestReplicaQuorumOfLayout(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	volume := volumeInfo.VolInfo.Volumes.Volume[0]
+	quorum, err := volumeClientQuorum(volume)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a data brick of the first set and the arbiter of the second set go down
+	online := onlineBricks(volumeStatus)
+	delete(online, "node1.example.local:/bricks/gv_arbiter/b1")
+	delete(online, "node3.example.local:/bricks/gv_arbiter/arbiter2")
+	for _, health := range subvolumeHealths(volume, online) {
+		if !quorum.met(health) {
+			t.Errorf("%v: expected quorum with %v of %v bricks", health.name, health.up, health.bricks)
+		}
+	}
+
+	delete(online, "node2.example.local:/bricks/gv_arbiter/b1")
+	health := subvolumeHealths(volume, online)[0]
+	if quorum.met(health) || !health.arbiterUp {
+		t.Errorf("%v: expected no quorum with only the arbiter up, got %+v", health.name, health)
+	}
+}
+
+func TestReplicatedVolumeStopped(t *testing.T) {
+	volumeInfo, _ := readLayoutFixtures(t)
+	// the bricks of a stopped volume are all offline and its replica sets would lose quorum
+	stopped := volumeInfo.VolInfo.Volumes.Volume[0]
+	stopped.Status = 2
+	if redundantVolume(stopped) {
+		t.Error("Expected no quorum of the replica sets of a stopped volume")
+	}
+}
+
+func TestServerQuorumRatio(t *testing.T) {
+	file, err := os.Open("test/gluster_volume_get_all_server_quorum_ratio.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	volGet, err := structs.VolumeGetXMLUnmarshall(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ratio := serverQuorumRatio(volGet.VolGetOpts)
+	if ratio != "90%" {
+		t.Fatalf("want ratio 90%%, got %q", ratio)
+	}
+	// 3 of 4 nodes don't reach 90%
+	peers := []structs.Peer{
+		{Connected: 1, State: peerStateInCluster},
+		{Connected: 1, State: peerStateInCluster},
+		{Connected: 0, State: peerStateInCluster},
+	}
+	if met, err := hasServerQuorum(peers, ratio); err != nil || met {
+		t.Errorf("want no server quorum with 3 of 4 nodes, got %v (%v)", met, err)
+	}
+
+	unset := structs.VolGetOpts{Opt: []structs.VolGetOpt{{Option: serverQuorumRatioOption, Value: "(null)"}}}
+	if ratio := serverQuorumRatio(unset); ratio != "" {
+		t.Errorf("want no ratio if unset, got %q", ratio)
+	}
+}
+
+func TestHasServerQuorum(t *testing.T) {
+	peers := []structs.Peer{
+		{Connected: 1, State: peerStateInCluster},
+		{Connected: 0, State: peerStateInCluster},
+		{Connected: 0, State: peerStateInCluster},
+	}
+	// a rejected peer and a peer which hasn't accepted the probe yet aren't members of the pool
+	pending := append(peers[:2:2], structs.Peer{Connected: 0, State: 6}, structs.Peer{Connected: 0, State: 1})
+	tests := []struct {
+		peers []structs.Peer
+		ratio string
+		want  bool
+		err   bool
+	}{
+		// 2 of 4 nodes aren't more than half
+		{peers, "", false, false},
+		{peers[:2], "", true, false},
+		// 2 of 3 members, the disconnected peers outside the pool would make it 2 of 5
+		{pending, "", true, false},
+		{pending, "67%", false, false},
+		{peers, "50%", true, false},
+		{peers, "51", false, false},
+		{nil, "", true, false},
+		{peers, "half", false, true},
+	}
+	for _, c := range tests {
+		got, err := hasServerQuorum(c.peers, c.ratio)
+		if got != c.want || (err != nil) != c.err {
+			t.Errorf("hasServerQuorum(%v peers, %q) == (%v, %v), want %v", len(c.peers), c.ratio, got, err, c.want)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+const (
+	// kinds of quotas
+	quotaKindData    = "data"
+	quotaKindObjects = "objects"
+)
+
+// quotaEntry is the limit and usage of a quota path, either in bytes or in files and directories
+type quotaEntry struct {
+	path       string
+	hardLimit  uint64
+	softLimit  uint64
+	used       uint64
+	available  uint64
+	files      uint64
+	dirs       uint64
+	slExceeded bool
+	hlExceeded bool
+}
+
+// dataQuotaUsages converts the limits of "gluster volume quota VOLNAME list"
+func dataQuotaUsages(limits []structs.QuotaLimit) []quotaEntry {
+	usages := make([]quotaEntry, 0, len(limits))
+	for _, limit := range limits {
+		usages = append(usages, quotaEntry{
+			path:       limit.Path,
+			hardLimit:  limit.HardLimit,
+			softLimit:  limit.SoftLimitValue,
+			used:       limit.UsedSpace,
+			available:  limit.AvailSpace,
+			slExceeded: limit.SlExceeded != "No",
+			hlExceeded: limit.HlExceeded != "No",
+		})
+	}
+	return usages
+}
+
+// objectQuotaUsages converts the limits of "gluster volume quota VOLNAME list-objects"
+func objectQuotaUsages(limits []structs.QuotaObjectLimit) []quotaEntry {
+	usages := make([]quotaEntry, 0, len(limits))
+	for _, limit := range limits {
+		usages = append(usages, quotaEntry{
+			path:       limit.Path,
+			hardLimit:  limit.HardLimit,
+			softLimit:  limit.SoftLimitValue,
+			used:       limit.FileCount + limit.DirCount,
+			available:  limit.Available,
+			files:      limit.FileCount,
+			dirs:       limit.DirCount,
+			slExceeded: limit.SlExceeded != "No",
+			hlExceeded: limit.HlExceeded != "No",
+		})
+	}
+	return usages
+}
+
+// quotaPathFilter limits the number of quota paths exported per volume. Paths are filtered by
+// include and exclude expressions, optionally aggregated to a path depth and capped to the paths
+// closest to their hard limit.
+type quotaPathFilter struct {
+	include  *regexp.Regexp
+	exclude  *regexp.Regexp
+	depth    int
+	maxPaths int
+}
+
+func newQuotaPathFilter(include, exclude string, depth, maxPaths int) (*quotaPathFilter, error) {
+	f := &quotaPathFilter{depth: depth, maxPaths: maxPaths}
+	var err error
+	if f.include, err = compileAnchored(include); err != nil {
+		return nil, err
+	}
+	if len(exclude) > 0 {
+		if f.exclude, err = compileAnchored(exclude); err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// apply filters, aggregates and caps the quotas of a volume. It returns the quotas to export and
+// the number of paths dropped because of the cap.
+func (f *quotaPathFilter) apply(usages []quotaEntry) ([]quotaEntry, int) {
+	filtered := make([]quotaEntry, 0, len(usages))
+	for _, usage := range usages {
+		if !f.include.MatchString(usage.path) || (f.exclude != nil && f.exclude.MatchString(usage.path)) {
+			continue
+		}
+		filtered = append(filtered, usage)
+	}
+	if f.depth > 0 {
+		filtered = aggregateQuotaUsages(filtered, f.depth)
+	}
+	if f.maxPaths <= 0 || len(filtered) <= f.maxPaths {
+		return filtered, 0
+	}
+	sort.SliceStable(filtered, func(i, j int) bool {
+		return quotaFillRatio(filtered[i]) > quotaFillRatio(filtered[j])
+	})
+	return filtered[:f.maxPaths], len(filtered) - f.maxPaths
+}
+
+// quotaFillRatio is used to keep the quotas closest to their hard limit, limits of 0 come last
+func quotaFillRatio(usage quotaEntry) float64 {
+	ratio, _ := quotaUsageRatio(usage.used, usage.hardLimit)
+	return ratio
+}
+
+// truncateQuotaPath shortens a quota path to depth directories
+func truncateQuotaPath(quotaPath string, depth int) string {
+	dirs := strings.Split(strings.Trim(quotaPath, "/"), "/")
+	if len(dirs) <= depth {
+		return quotaPath
+	}
+	return "/" + strings.Join(dirs[:depth], "/")
+}
+
+// aggregateQuotaUsages sums the quotas below depth into their ancestor directory at depth. Quotas of a path
+// already include the usage of nested quotas, so nested quotas are skipped if one of their ancestors
+// is part of the same aggregate.
+func aggregateQuotaUsages(usages []quotaEntry, depth int) []quotaEntry {
+	paths := make(map[string]bool, len(usages))
+	for _, usage := range usages {
+		paths[usage.path] = true
+	}
+
+	var aggregated []quotaEntry
+	index := make(map[string]int)
+	for _, usage := range usages {
+		key := truncateQuotaPath(usage.path, depth)
+		if hasQuotaAncestor(usage.path, key, paths) {
+			continue
+		}
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(aggregated)
+			usage.path = key
+			aggregated = append(aggregated, usage)
+			continue
+		}
+		sum := &aggregated[i]
+		sum.hardLimit += usage.hardLimit
+		sum.softLimit += usage.softLimit
+		sum.used += usage.used
+		sum.available += usage.available
+		sum.files += usage.files
+		sum.dirs += usage.dirs
+		sum.slExceeded = sum.slExceeded || usage.slExceeded
+		sum.hlExceeded = sum.hlExceeded || usage.hlExceeded
+	}
+	return aggregated
+}
+
+// hasQuotaAncestor reports whether one of the parent directories of quotaPath up to key has a quota
+func hasQuotaAncestor(quotaPath, key string, paths map[string]bool) bool {
+	for quotaPath != key && quotaPath != "/" && quotaPath != "." {
+		quotaPath = path.Dir(quotaPath)
+		if paths[quotaPath] {
+			return true
+		}
+	}
+	return false
+}
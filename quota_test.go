@@ -0,0 +1,92 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestQuotaPathFilterInclude(t *testing.T) {
+	f, err := newQuotaPathFilter("/users/.*", "/users/tmp.*", 0, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usages := []quotaEntry{
+		{path: "/users/alice"},
+		{path: "/users/tmp1"},
+		{path: "/projects/x"},
+		{path: "/users/bob"},
+	}
+	got, dropped := f.apply(usages)
+	want := []quotaEntry{{path: "/users/alice"}, {path: "/users/bob"}}
+	if !reflect.DeepEqual(got, want) || dropped != 0 {
+		t.Errorf("want: %v, got: %v (%v dropped)", want, got, dropped)
+	}
+
+	if _, err := newQuotaPathFilter("(", "", 0, 0); err == nil {
+		t.Error("Expected error for invalid expression")
+	}
+}
+
+func TestQuotaPathFilterMaxPaths(t *testing.T) {
+	f, err := newQuotaPathFilter("", "", 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	usages := []quotaEntry{
+		{path: "/a", used: 10, hardLimit: 100},
+		{path: "/b", used: 90, hardLimit: 100},
+		{path: "/c", used: 10},
+		{path: "/d", used: 50, hardLimit: 100},
+	}
+	got, dropped := f.apply(usages)
+	want := []quotaEntry{
+		{path: "/b", used: 90, hardLimit: 100},
+		{path: "/d", used: 50, hardLimit: 100},
+	}
+	if !reflect.DeepEqual(got, want) || dropped != 2 {
+		t.Errorf("want: %v, got: %v (%v dropped)", want, got, dropped)
+	}
+
+	// the same paths are dropped again on the next scrape
+	if _, again := f.apply(usages); again != 2 {
+		t.Errorf("Expected 2 dropped paths again, got %v", again)
+	}
+}
+
+func TestAggregateQuotaUsages(t *testing.T) {
+	usages := []quotaEntry{
+		{path: "/users/alice", hardLimit: 100, used: 40, files: 4},
+		{path: "/users/alice/docs", hardLimit: 50, used: 30, files: 3},
+		{path: "/users/bob", hardLimit: 100, used: 70, files: 7, slExceeded: true},
+		{path: "/projects/x/data", hardLimit: 10, used: 1},
+		{path: "/projects", hardLimit: 1000, used: 500},
+		{path: "/scratch", hardLimit: 20, used: 2},
+	}
+	got := aggregateQuotaUsages(usages, 1)
+	want := []quotaEntry{
+		{path: "/users", hardLimit: 200, used: 110, files: 11, slExceeded: true},
+		{path: "/projects", hardLimit: 1000, used: 500},
+		{path: "/scratch", hardLimit: 20, used: 2},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("want: %v, got: %v", want, got)
+	}
+}
+
+func TestTruncateQuotaPath(t *testing.T) {
+	tests := []struct {
+		path  string
+		depth int
+		want  string
+	}{
+		{"/", 1, "/"},
+		{"/users", 1, "/users"},
+		{"/users/alice/docs", 1, "/users"},
+		{"/users/alice/docs", 2, "/users/alice"},
+	}
+	for _, c := range tests {
+		if got := truncateQuotaPath(c.path, c.depth); got != c.want {
+			t.Errorf("truncateQuotaPath(%q, %v) == %q, want %q", c.path, c.depth, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"github.com/ofesseler/gluster_exporter/structs"
+)
+
+// onlineBricks returns the online bricks of "gluster volume status" keyed by "hostname:path". Bricks of
+// disconnected peers aren't listed at all and count as offline.
+func onlineBricks(volumeStatus structs.VolumeStatusXML) map[string]bool {
+	online := make(map[string]bool)
+	for _, vol := range volumeStatus.VolStatus.Volumes.Volume {
+		for _, node := range vol.Node {
+			if node.Hostname == selfHealDaemonHostname || node.Status != 1 {
+				continue
+			}
+			online[node.Hostname+":"+node.Path] = true
+		}
+	}
+	return online
+}
+
+// redundantVolume reports whether the health of the replica or disperse sets of a volume is exported. Only started
+// volumes count, the bricks of a stopped volume are offline on purpose and would report every set as failed.
+func redundantVolume(volume structs.Volume) bool {
+	return volume.Status == 1 && (volume.DisperseCount > 0 || volume.ReplicaCount > 1)
+}
+
+// subvolumeHealth is the number of bricks of a replica or disperse set and how many of them are online
+type subvolumeHealth struct {
+	name   string
+	bricks int
+	up     int
+	// firstUp is set if the first brick of the set is online, it breaks ties of client quorum
+	firstUp   bool
+	arbiter   bool
+	arbiterUp bool
+}
+
+// subvolumeHealths returns the health of the subvolumes of a volume
+func subvolumeHealths(volume structs.Volume, online map[string]bool) []subvolumeHealth {
+	var healths []subvolumeHealth
+	for i, bricks := range subvolumes(volume) {
+		health := subvolumeHealth{name: subvolumeName(volume, i), bricks: len(bricks)}
+		for j, brick := range bricks {
+			if brick.IsArbiter == 1 {
+				health.arbiter = true
+				health.arbiterUp = online[brick.Name]
+			}
+			if online[brick.Name] {
+				health.up++
+				health.firstUp = health.firstUp || j == 0
+			}
+		}
+		healths = append(healths, health)
+	}
+	return healths
+}
+
+// disperseFailuresTolerated returns how many more bricks of a disperse set may fail before its data becomes
+// unavailable. A disperse set needs disperse - redundancy bricks to read and write, the result is negative once
+// fewer are online.
+func disperseFailuresTolerated(volume structs.Volume, health subvolumeHealth) int {
+	return health.up - (volume.DisperseCount - volume.RedundancyCount)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedundantVolume(t *testing.T) {
+	volumeInfo, _ := readLayoutFixtures(t)
+	want := map[string]bool{"gv_arbiter": true, "gv_disperse": true, "gv_dist": false}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		if redundant := redundantVolume(volume); redundant != want[volume.Name] {
+			t.Errorf("%v: want %v, got %v", volume.Name, want[volume.Name], redundant)
+		}
+	}
+
+	// the bricks of a stopped volume are all offline
+	stopped := volumeInfo.VolInfo.Volumes.Volume[1]
+	stopped.Status = 2
+	if redundantVolume(stopped) {
+		t.Error("Expected no health of the disperse sets of a stopped volume")
+	}
+}
+
+func TestSubvolumeHealths(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	online := onlineBricks(volumeStatus)
+
+	want := map[string][]subvolumeHealth{
+		"gv_arbiter": {
+			{name: "gv_arbiter-replicate-0", bricks: 3, up: 3, firstUp: true, arbiter: true, arbiterUp: true},
+			{name: "gv_arbiter-replicate-1", bricks: 3, up: 3, firstUp: true, arbiter: true, arbiterUp: true},
+		},
+		"gv_disperse": {
+			{name: "gv_disperse-disperse-0", bricks: 6, up: 5, firstUp: true},
+		},
+		"gv_dist": {
+			{name: "gv_dist-client-0", bricks: 1, up: 1, firstUp: true},
+			{name: "gv_dist-client-1", bricks: 1, up: 1, firstUp: true},
+			{name: "gv_dist-client-2", bricks: 1, up: 1, firstUp: true},
+		},
+	}
+	for _, volume := range volumeInfo.VolInfo.Volumes.Volume {
+		if got := subvolumeHealths(volume, online); !reflect.DeepEqual(got, want[volume.Name]) {
+			t.Errorf("%v: want %+v, got %+v", volume.Name, want[volume.Name], got)
+		}
+	}
+}
+
+func TestDisperseFailuresTolerated(t *testing.T) {
+	volumeInfo, volumeStatus := readLayoutFixtures(t)
+	volume := volumeInfo.VolInfo.Volumes.Volume[1]
+
+	// one of 6 bricks with redundancy 2 is offline
+	health := subvolumeHealths(volume, onlineBricks(volumeStatus))[0]
+	if tolerated := disperseFailuresTolerated(volume, health); tolerated != 1 {
+		t.Errorf("want 1 further failure tolerated, got %v", tolerated)
+	}
+
+	// bricks of a disconnected peer aren't listed by gluster volume status
+	volumeStatus.VolStatus.Volumes.Volume[1].Node = volumeStatus.VolStatus.Volumes.Volume[1].Node[:3]
+	health = subvolumeHealths(volume, onlineBricks(volumeStatus))[0]
+	if tolerated := disperseFailuresTolerated(volume, health); tolerated != -1 {
+		t.Errorf("want -1 further failures tolerated, got %v", tolerated)
+	}
+}
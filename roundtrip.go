@@ -0,0 +1,176 @@
+package main
+
+import (
+	"fmt"
+	"hash/crc32"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	// DefaultProbeDir is the directory below each mount point the probes work in
+	DefaultProbeDir = ".gluster_exporter"
+
+	// phases of the round trip probe
+	roundTripPhaseWrite  = "write"
+	roundTripPhaseFsync  = "fsync"
+	roundTripPhaseRead   = "read"
+	roundTripPhaseStat   = "stat"
+	roundTripPhaseDelete = "delete"
+)
+
+// roundTripProbe writes a file to a mount, fsyncs it, reads it back and verifies its checksum, stats and
+// deletes it. The latency of each phase is observed in a histogram per mount.
+type roundTripProbe struct {
+	hostname string
+	dir      string
+	size     int
+
+	latency  *prometheus.HistogramVec
+	failures *prometheus.CounterVec
+
+	mu sync.Mutex
+	// probed are the mounts with label values in latency and failures
+	probed map[probedMount]bool
+}
+
+// probedMount are the volume and mountpoint labels of the probe metrics of a mount
+type probedMount struct {
+	volume     string
+	mountPoint string
+}
+
+// roundTripPhases are all phases of the round trip probe
+var roundTripPhases = []string{
+	roundTripPhaseWrite,
+	roundTripPhaseFsync,
+	roundTripPhaseRead,
+	roundTripPhaseStat,
+	roundTripPhaseDelete,
+}
+
+func newRoundTripProbe(hostname, dir string, size int) *roundTripProbe {
+	return &roundTripProbe{
+		hostname: hostname,
+		dir:      dir,
+		size:     size,
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "mount_probe_duration_seconds",
+			Help:      "Duration of the phases of the read/write round trip probe of a mount",
+			Buckets:   prometheus.ExponentialBuckets(0.001, 2, 14),
+		}, []string{"volume", "mountpoint", "phase"}),
+		failures: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "mount_probe_failures_total",
+			Help:      "Number of failed phases of the read/write round trip probe of a mount",
+		}, []string{"volume", "mountpoint", "phase"}),
+		probed: make(map[probedMount]bool),
+	}
+}
+
+// run probes a mount. The returned error is the unwrapped error of the failed phase, so it can be classified.
+func (p *roundTripProbe) run(volume, mountPoint string) error {
+	p.mu.Lock()
+	p.probed[probedMount{volume: volume, mountPoint: mountPoint}] = true
+	p.mu.Unlock()
+
+	dir := filepath.Join(mountPoint, p.dir)
+	name := filepath.Join(dir, fmt.Sprintf("roundtrip.%v.%v", p.hostname, time.Now().UnixNano()))
+	data := make([]byte, p.size)
+	rand.Read(data)
+	checksum := crc32.ChecksumIEEE(data)
+
+	var file *os.File
+	phases := []struct {
+		name  string
+		phase func() error
+	}{
+		{roundTripPhaseWrite, func() error {
+			if err := makeProbeDir(dir); err != nil {
+				return err
+			}
+			var err error
+			if file, err = os.OpenFile(name, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644); err != nil {
+				return err
+			}
+			_, err = file.Write(data)
+			return err
+		}},
+		{roundTripPhaseFsync, func() error {
+			return file.Sync()
+		}},
+		{roundTripPhaseRead, func() error {
+			err := file.Close()
+			file = nil
+			if err != nil {
+				return err
+			}
+			content, err := ioutil.ReadFile(name)
+			if err != nil {
+				return err
+			}
+			if len(content) != len(data) || crc32.ChecksumIEEE(content) != checksum {
+				return fmt.Errorf("content of %v differs from the written data", name)
+			}
+			return nil
+		}},
+		{roundTripPhaseStat, func() error {
+			info, err := os.Stat(name)
+			if err != nil {
+				return err
+			}
+			if info.Size() != int64(len(data)) {
+				return fmt.Errorf("size of %v is %v instead of %v", name, info.Size(), len(data))
+			}
+			return nil
+		}},
+		{roundTripPhaseDelete, func() error {
+			return os.Remove(name)
+		}},
+	}
+	for _, phase := range phases {
+		start := time.Now()
+		if err := phase.phase(); err != nil {
+			p.failures.WithLabelValues(volume, mountPoint, phase.name).Inc()
+			if file != nil {
+				file.Close()
+			}
+			os.Remove(name)
+			return err
+		}
+		p.latency.WithLabelValues(volume, mountPoint, phase.name).Observe(time.Since(start).Seconds())
+	}
+	return nil
+}
+
+// prune deletes the metrics of mounts which aren't mounted anymore, so unmounted or remounted volumes don't
+// export their last values forever
+func (p *roundTripProbe) prune(mounted map[probedMount]bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for mount := range p.probed {
+		if mounted[mount] {
+			continue
+		}
+		for _, phase := range roundTripPhases {
+			p.latency.DeleteLabelValues(mount.volume, mount.mountPoint, phase)
+			p.failures.DeleteLabelValues(mount.volume, mount.mountPoint, phase)
+		}
+		delete(p.probed, mount)
+	}
+}
+
+// makeProbeDir creates the probe directory of a mount. Unlike os.MkdirAll it fails if the mount point is missing.
+func makeProbeDir(dir string) error {
+	if err := os.Mkdir(dir, 0755); err != nil && !os.IsExist(err) {
+		return err
+	}
+	return nil
+}
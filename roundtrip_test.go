@@ -0,0 +1,87 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+)
+
+func TestRoundTripProbe(t *testing.T) {
+	mountPoint, err := ioutil.TempDir("", "gluster_exporter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(mountPoint)
+
+	probe := newRoundTripProbe("node1", DefaultProbeDir, 8192)
+	if err := probe.run("gv_test", mountPoint); err != nil {
+		t.Fatal(err)
+	}
+
+	files, err := ioutil.ReadDir(filepath.Join(mountPoint, DefaultProbeDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 0 {
+		t.Errorf("Expected probe file to be deleted, found %v files", len(files))
+	}
+
+	for _, phase := range roundTripPhases {
+		var metric dto.Metric
+		if err := probe.latency.WithLabelValues("gv_test", mountPoint, phase).(prometheus.Metric).Write(&metric); err != nil {
+			t.Fatal(err)
+		}
+		if count := metric.GetHistogram().GetSampleCount(); count != 1 {
+			t.Errorf("Expected 1 observation of phase %v, got %v", phase, count)
+		}
+	}
+}
+
+func TestRoundTripProbeFailure(t *testing.T) {
+	probe := newRoundTripProbe("node1", DefaultProbeDir, 16)
+	if err := probe.run("gv_test", "test/nonexistent"); err == nil {
+		t.Fatal("Expected probe of missing mount point to fail")
+	}
+
+	var metric dto.Metric
+	if err := probe.failures.WithLabelValues("gv_test", "test/nonexistent", roundTripPhaseWrite).Write(&metric); err != nil {
+		t.Fatal(err)
+	}
+	if failures := metric.GetCounter().GetValue(); failures != 1 {
+		t.Errorf("Expected 1 failure of the write phase, got %v", failures)
+	}
+}
+
+// collectedMetrics returns the number of metrics a collector exports
+func collectedMetrics(c prometheus.Collector) int {
+	ch := make(chan prometheus.Metric)
+	go func() {
+		c.Collect(ch)
+		close(ch)
+	}()
+	count := 0
+	for range ch {
+		count++
+	}
+	return count
+}
+
+func TestRoundTripProbePrune(t *testing.T) {
+	probe := newRoundTripProbe("node1", DefaultProbeDir, 16)
+	probe.run("gv_test", "test/nonexistent")
+	probe.run("gv_test2", "test/nonexistent2")
+
+	probe.prune(map[probedMount]bool{{volume: "gv_test", mountPoint: "test/nonexistent"}: true})
+	if count := collectedMetrics(probe.failures); count != 1 {
+		t.Errorf("Expected failures of the mounted volume only, got %v", count)
+	}
+
+	probe.prune(map[probedMount]bool{})
+	if count := collectedMetrics(probe.failures); count != 0 {
+		t.Errorf("Expected no failures after unmount, got %v", count)
+	}
+}
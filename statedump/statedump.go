@@ -0,0 +1,289 @@
+// Package statedump parses the statedump files gluster processes write to /var/run/gluster
+// on "gluster volume statedump" or SIGUSR1.
+package statedump
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultDir is the directory gluster writes statedumps to
+	DefaultDir = "/var/run/gluster"
+
+	memUsageSuffix = " memusage"
+	memUsageType   = " - usage-type "
+	locksPrefix    = "xlator.features.locks."
+	callPoolPrefix = "global.callpool.stack."
+)
+
+// KeyValue is one key=value line of a section
+type KeyValue struct {
+	Key   string
+	Value string
+}
+
+// Section is a [name] block of a statedump
+type Section struct {
+	Name   string
+	Values []KeyValue
+}
+
+// Get returns the value of the first key in the section and whether it was found
+func (s Section) Get(key string) (string, bool) {
+	for _, kv := range s.Values {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return "", false
+}
+
+// Dump holds all sections of one statedump file
+type Dump struct {
+	StartTime string
+	EndTime   string
+	Sections  []Section
+}
+
+// MemoryUsage is the memory accounting of one xlator summed over all its usage types
+type MemoryUsage struct {
+	Size      uint64
+	NumAllocs uint64
+}
+
+// LockCount is the number of locks of one type and state held or requested in a locks xlator
+type LockCount struct {
+	Xlator string
+	Type   string
+	State  string
+	Count  int
+}
+
+// Parse reads a statedump
+func Parse(r io.Reader) (Dump, error) {
+	var dump Dump
+	var section *Section
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			continue
+		case strings.HasPrefix(line, "DUMP-START-TIME:"):
+			dump.StartTime = strings.TrimSpace(strings.TrimPrefix(line, "DUMP-START-TIME:"))
+		case strings.HasPrefix(line, "DUMP-END-TIME:"):
+			dump.EndTime = strings.TrimSpace(strings.TrimPrefix(line, "DUMP-END-TIME:"))
+		case strings.HasPrefix(line, "[") && strings.HasSuffix(line, "]"):
+			dump.Sections = append(dump.Sections, Section{Name: line[1 : len(line)-1]})
+			section = &dump.Sections[len(dump.Sections)-1]
+		default:
+			if section == nil {
+				return dump, fmt.Errorf("line outside of section: %q", line)
+			}
+			kv := strings.SplitN(line, "=", 2)
+			if len(kv) != 2 {
+				// some xlators dump free text, e.g. lists of fds
+				continue
+			}
+			section.Values = append(section.Values, KeyValue{Key: kv[0], Value: kv[1]})
+		}
+	}
+	return dump, scanner.Err()
+}
+
+// ParseFile reads the statedump at path
+func ParseFile(path string) (Dump, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Dump{}, err
+	}
+	defer f.Close()
+	return Parse(f)
+}
+
+// Complete reports whether the process finished writing the statedump, the end time is written last
+func (d Dump) Complete() bool {
+	return len(d.EndTime) > 0
+}
+
+// MemoryUsage sums the "usage-type ... memusage" sections per xlator
+func (d Dump) MemoryUsage() map[string]MemoryUsage {
+	usage := make(map[string]MemoryUsage)
+	for _, section := range d.Sections {
+		if !strings.HasSuffix(section.Name, memUsageSuffix) {
+			continue
+		}
+		i := strings.Index(section.Name, memUsageType)
+		if i < 0 {
+			continue
+		}
+		xlator := section.Name[:i]
+		u := usage[xlator]
+		u.Size += sectionUint(section, "size")
+		u.NumAllocs += sectionUint(section, "num_allocs")
+		usage[xlator] = u
+	}
+	return usage
+}
+
+// Locks counts the inodelk, entrylk and posixlk entries of all locks xlators by state,
+// e.g. ACTIVE or BLOCKED
+func (d Dump) Locks() []LockCount {
+	counts := make(map[LockCount]int)
+	for _, section := range d.Sections {
+		if !strings.HasPrefix(section.Name, locksPrefix) {
+			continue
+		}
+		// xlator.features.locks.{xlator}.inode
+		xlator := strings.TrimSuffix(strings.TrimPrefix(section.Name, locksPrefix), ".inode")
+		for _, kv := range section.Values {
+			lockType, state, ok := parseLockKey(kv.Key)
+			if !ok {
+				continue
+			}
+			counts[LockCount{Xlator: xlator, Type: lockType, State: state}]++
+		}
+	}
+
+	locks := make([]LockCount, 0, len(counts))
+	for lock, count := range counts {
+		lock.Count = count
+		locks = append(locks, lock)
+	}
+	sort.Slice(locks, func(i, j int) bool {
+		if locks[i].Xlator != locks[j].Xlator {
+			return locks[i].Xlator < locks[j].Xlator
+		}
+		if locks[i].Type != locks[j].Type {
+			return locks[i].Type < locks[j].Type
+		}
+		return locks[i].State < locks[j].State
+	})
+	return locks
+}
+
+// parseLockKey splits keys like "inodelk.inodelk[0](ACTIVE)" into lock type and state
+func parseLockKey(key string) (string, string, bool) {
+	open := strings.LastIndex(key, "(")
+	if open < 0 || !strings.HasSuffix(key, ")") {
+		return "", "", false
+	}
+	dot := strings.Index(key, ".")
+	if dot < 0 || dot > open {
+		return "", "", false
+	}
+	lockType := key[:dot]
+	switch lockType {
+	case "inodelk", "entrylk", "posixlk":
+		return lockType, key[open+1 : len(key)-1], true
+	}
+	return "", "", false
+}
+
+// CallStacks returns the number of pending call stacks in the call pool
+func (d Dump) CallStacks() int {
+	count := 0
+	for _, section := range d.Sections {
+		if !strings.HasPrefix(section.Name, callPoolPrefix) {
+			continue
+		}
+		// frames are dumped as global.callpool.stack.{n}.frame.{m}
+		if !strings.Contains(strings.TrimPrefix(section.Name, callPoolPrefix), ".") {
+			count++
+		}
+	}
+	return count
+}
+
+func sectionUint(section Section, key string) uint64 {
+	value, ok := section.Get(key)
+	if !ok {
+		return 0
+	}
+	n, err := strconv.ParseUint(strings.TrimSpace(value), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+// File is a statedump file written by a gluster process
+type File struct {
+	Path string
+	// Process identifies the dumping process, e.g. "mnt-gluster-gv_test.1342" for a brick
+	// or "glusterdump.2311" for a client
+	Process string
+	Time    time.Time
+}
+
+// Pid returns the pid of the dumping process, the suffix of Process
+func (f File) Pid() (int, bool) {
+	_, pid, ok := f.splitProcess()
+	return pid, ok
+}
+
+// Name returns Process without the pid, e.g. "mnt-gluster-gv_test" for the brick /mnt/gluster/gv_test or
+// "glusterdump" for a client. Unlike the pid it doesn't change when the process restarts.
+func (f File) Name() string {
+	name, _, _ := f.splitProcess()
+	return name
+}
+
+// splitProcess splits Process into the name and the pid of the dumping process
+func (f File) splitProcess() (string, int, bool) {
+	i := strings.LastIndex(f.Process, ".")
+	if i < 0 {
+		return f.Process, 0, false
+	}
+	pid, err := strconv.Atoi(f.Process[i+1:])
+	if err != nil || pid <= 0 {
+		return f.Process, 0, false
+	}
+	return f.Process[:i], pid, true
+}
+
+// parseFileName splits statedump file names like "{process}.dump.{unix timestamp}"
+func parseFileName(name string) (string, time.Time, bool) {
+	i := strings.LastIndex(name, ".dump.")
+	if i < 1 {
+		return "", time.Time{}, false
+	}
+	seconds, err := strconv.ParseInt(name[i+len(".dump."):], 10, 64)
+	if err != nil {
+		return "", time.Time{}, false
+	}
+	return name[:i], time.Unix(seconds, 0), true
+}
+
+// Files lists the statedump files in dir, grouped by process and sorted newest first
+func Files(dir string) (map[string][]File, error) {
+	entries, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	files := make(map[string][]File)
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		process, t, ok := parseFileName(entry.Name())
+		if !ok {
+			continue
+		}
+		files[process] = append(files[process], File{Path: filepath.Join(dir, entry.Name()), Process: process, Time: t})
+	}
+	for _, processFiles := range files {
+		sort.Slice(processFiles, func(i, j int) bool { return processFiles[i].Time.After(processFiles[j].Time) })
+	}
+	return files, nil
+}
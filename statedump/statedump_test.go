@@ -0,0 +1,140 @@
+package statedump
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+const testDumpPath = "../test/mnt-gluster-gv_test.1342.dump.1494417600"
+
+func TestParseFile(t *testing.T) {
+	dump, err := ParseFile(testDumpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if dump.StartTime != "2017-05-10 12:00:00.118382" {
+		t.Errorf("Unexpected start time %v", dump.StartTime)
+	}
+
+	if len(dump.Sections) != 17 {
+		t.Errorf("Expected 17 sections and len is %v", len(dump.Sections))
+	}
+
+	value, ok := dump.Sections[0].Get("mallinfo_arena")
+	if !ok || value != "12902400" {
+		t.Errorf("Expected mallinfo_arena 12902400, got %v", value)
+	}
+
+	if !dump.Complete() {
+		t.Error("Expected statedump with end time to be complete")
+	}
+	if partial, _ := Parse(strings.NewReader("DUMP-START-TIME: 2017-05-10 12:00:00.118382\n[mallinfo]\n")); partial.Complete() {
+		t.Error("Expected statedump without end time to be incomplete")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	if _, err := Parse(strings.NewReader("size=16\n")); err == nil {
+		t.Error("Expected error for key outside of section")
+	}
+}
+
+func TestMemoryUsage(t *testing.T) {
+	dump, err := ParseFile(testDumpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	usage := dump.MemoryUsage()
+	if len(usage) != 3 {
+		t.Fatalf("Expected 3 xlators and got %v", usage)
+	}
+
+	posix := usage["storage/posix.gv_test-posix"]
+	if posix.Size != 4160 || posix.NumAllocs != 129 {
+		t.Errorf("Unexpected memory usage of posix xlator %v", posix)
+	}
+
+	global := usage["global.glusterfs"]
+	if global.Size != 1040 || global.NumAllocs != 13 {
+		t.Errorf("Unexpected memory usage of global.glusterfs %v", global)
+	}
+}
+
+func TestLocks(t *testing.T) {
+	dump, err := ParseFile(testDumpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []LockCount{
+		{Xlator: "gv_test-locks", Type: "entrylk", State: "ACTIVE", Count: 1},
+		{Xlator: "gv_test-locks", Type: "inodelk", State: "ACTIVE", Count: 1},
+		{Xlator: "gv_test-locks", Type: "inodelk", State: "BLOCKED", Count: 2},
+		{Xlator: "gv_test-locks", Type: "posixlk", State: "ACTIVE", Count: 1},
+	}
+	locks := dump.Locks()
+	if len(locks) != len(expected) {
+		t.Fatalf("Expected %v and got %v", expected, locks)
+	}
+	for i, lock := range locks {
+		if lock != expected[i] {
+			t.Errorf("Expected %v and got %v", expected[i], lock)
+		}
+	}
+}
+
+func TestCallStacks(t *testing.T) {
+	dump, err := ParseFile(testDumpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if count := dump.CallStacks(); count != 2 {
+		t.Errorf("Expected 2 call stacks and got %v", count)
+	}
+}
+
+func TestFiles(t *testing.T) {
+	files, err := Files("../test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	processFiles, ok := files["mnt-gluster-gv_test.1342"]
+	if !ok || len(processFiles) != 1 {
+		t.Fatalf("Expected 1 statedump of mnt-gluster-gv_test.1342 and got %v", files)
+	}
+
+	if !processFiles[0].Time.Equal(time.Unix(1494417600, 0)) {
+		t.Errorf("Unexpected statedump time %v", processFiles[0].Time)
+	}
+
+	if pid, ok := processFiles[0].Pid(); !ok || pid != 1342 {
+		t.Errorf("Expected pid 1342, got %v", pid)
+	}
+	if name := processFiles[0].Name(); name != "mnt-gluster-gv_test" {
+		t.Errorf("Expected name mnt-gluster-gv_test, got %v", name)
+	}
+	if _, ok := (File{Process: "glusterdump"}).Pid(); ok {
+		t.Error("Expected no pid without suffix")
+	}
+	if name := (File{Process: "glusterdump"}).Name(); name != "glusterdump" {
+		t.Errorf("Expected name glusterdump without suffix, got %v", name)
+	}
+}
+
+func TestParseFileName(t *testing.T) {
+	process, dumpTime, ok := parseFileName("glusterdump.2311.dump.1494417601")
+	if !ok || process != "glusterdump.2311" || dumpTime.Unix() != 1494417601 {
+		t.Errorf("Unexpected result %v %v %v", process, dumpTime, ok)
+	}
+
+	for _, name := range []string{"gluster_volume_info.xml", ".dump.1494417601", "glusterd.dump.now"} {
+		if _, _, ok := parseFileName(name); ok {
+			t.Errorf("Expected %v not to be a statedump file name", name)
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"os"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/ofesseler/gluster_exporter/statedump"
+	"github.com/prometheus/common/log"
+)
+
+// statedumpReader reads the latest statedump of each gluster process and triggers new
+// statedumps of the local bricks every interval. An interval of 0 only reads the
+// statedumps which already exist.
+type statedumpReader struct {
+	dir      string
+	interval time.Duration
+	// running reports whether a process is alive, statedumps of dead processes are ignored
+	running func(pid int) bool
+
+	mu          sync.Mutex
+	lastTrigger time.Time
+	// triggered are the processes which were sent SIGUSR1 and the time, until their statedump showed up
+	triggered map[int]time.Time
+	// owned are the paths of statedumps triggered by the exporter, only these are ever removed
+	owned map[string]bool
+	// parsed are the complete statedumps by path, they don't change anymore once their end time is written
+	parsed map[string]statedump.Dump
+}
+
+func newStatedumpReader(dir string, interval time.Duration) *statedumpReader {
+	return &statedumpReader{
+		dir:       dir,
+		interval:  interval,
+		running:   processRunning,
+		triggered: make(map[int]time.Time),
+		owned:     make(map[string]bool),
+		parsed:    make(map[string]statedump.Dump),
+	}
+}
+
+// processRunning reports whether a process with pid exists
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
+
+// triggerDue reports whether new statedumps should be triggered and remembers the trigger time
+func (r *statedumpReader) triggerDue(now time.Time) bool {
+	if r.interval <= 0 {
+		return false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now.Sub(r.lastTrigger) < r.interval {
+		return false
+	}
+	r.lastTrigger = now
+	return true
+}
+
+// trigger makes a local gluster process write a statedump by sending it SIGUSR1. Unlike
+// "gluster volume statedump" this doesn't dump the bricks of other nodes.
+func (r *statedumpReader) trigger(pid int, now time.Time) error {
+	if err := syscall.Kill(pid, syscall.SIGUSR1); err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.triggered[pid] = now
+	return nil
+}
+
+// latestStatedump is the newest complete statedump of a process
+type latestStatedump struct {
+	statedump.File
+	dump statedump.Dump
+}
+
+// latest returns the newest complete statedump of each running process. Statedumps without an
+// end time are still being written and skipped. Processes with the same name without pid, e.g.
+// several fuse clients, only return the newest statedump of all of them. The first statedump of a process showing up
+// after the exporter triggered it is owned by the exporter, owned statedumps are removed once
+// superseded so they don't fill up the statedump directory. Other statedumps are left alone.
+func (r *statedumpReader) latest() ([]latestStatedump, error) {
+	files, err := statedump.Files(r.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	existing := make(map[string]bool)
+	latest := make([]latestStatedump, 0, len(files))
+	names := make(map[string]int)
+	for _, processFiles := range files {
+		for _, file := range processFiles {
+			existing[file.Path] = true
+		}
+		pid, ok := processFiles[0].Pid()
+		if !ok {
+			continue
+		}
+		if !r.running(pid) {
+			r.removeOwned(processFiles)
+			delete(r.triggered, pid)
+			continue
+		}
+		r.claim(pid, processFiles)
+
+		for i, file := range processFiles {
+			dump, ok := r.parse(file.Path)
+			if !ok {
+				continue
+			}
+			r.removeOwned(processFiles[i+1:])
+			if j, ok := names[file.Name()]; ok {
+				if file.Time.After(latest[j].Time) {
+					latest[j] = latestStatedump{File: file, dump: dump}
+				}
+				break
+			}
+			names[file.Name()] = len(latest)
+			latest = append(latest, latestStatedump{File: file, dump: dump})
+			break
+		}
+	}
+	for path := range r.owned {
+		if !existing[path] {
+			delete(r.owned, path)
+		}
+	}
+	for path := range r.parsed {
+		if !existing[path] {
+			delete(r.parsed, path)
+		}
+	}
+	return latest, nil
+}
+
+// parse returns a complete statedump, parsing it only the first time. Statedumps still being written aren't cached.
+func (r *statedumpReader) parse(path string) (statedump.Dump, bool) {
+	if dump, ok := r.parsed[path]; ok {
+		return dump, true
+	}
+	dump, err := statedump.ParseFile(path)
+	if err != nil {
+		log.Errorf("couldn't parse statedump %v: %v", path, err)
+		return dump, false
+	}
+	if !dump.Complete() {
+		return dump, false
+	}
+	r.parsed[path] = dump
+	return dump, true
+}
+
+// claim marks the oldest statedump written since a process was triggered as owned. Statedumps
+// are named by the second they were written in.
+func (r *statedumpReader) claim(pid int, files []statedump.File) {
+	triggered, ok := r.triggered[pid]
+	if !ok {
+		return
+	}
+	for i := len(files) - 1; i >= 0; i-- {
+		if !files[i].Time.Before(triggered.Truncate(time.Second)) && !r.owned[files[i].Path] {
+			r.owned[files[i].Path] = true
+			delete(r.triggered, pid)
+			return
+		}
+	}
+}
+
+// removeOwned removes the statedumps of files which the exporter triggered
+func (r *statedumpReader) removeOwned(files []statedump.File) {
+	for _, file := range files {
+		if !r.owned[file.Path] {
+			continue
+		}
+		if err := os.Remove(file.Path); err != nil && !os.IsNotExist(err) {
+			log.Errorf("couldn't remove superseded statedump: %v", err)
+			continue
+		}
+		delete(r.owned, file.Path)
+		delete(r.parsed, file.Path)
+	}
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestStatedumpReaderTriggerDue(t *testing.T) {
+	start := time.Unix(1494417600, 0)
+
+	reader := newStatedumpReader("test", 0)
+	if reader.triggerDue(start) {
+		t.Error("Expected no trigger without interval")
+	}
+
+	reader = newStatedumpReader("test", 5*time.Minute)
+	if !reader.triggerDue(start) {
+		t.Error("Expected first trigger to be due")
+	}
+	if reader.triggerDue(start.Add(time.Minute)) {
+		t.Error("Expected no trigger within interval")
+	}
+	if !reader.triggerDue(start.Add(5 * time.Minute)) {
+		t.Error("Expected trigger after interval")
+	}
+}
+
+func TestStatedumpReaderLatest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gluster_exporter_statedump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	complete := []byte("DUMP-START-TIME: 2017-05-10 12:00:00.118382\nDUMP-END-TIME: 2017-05-10 12:00:00.201937\n")
+	partial := []byte("DUMP-START-TIME: 2017-05-10 12:10:00.118382\n")
+	writeDump := func(name string, content []byte) string {
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, content, 0600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+	exists := func(path string) bool {
+		_, err := os.Stat(path)
+		return err == nil
+	}
+
+	admin := writeDump("mnt-gluster-gv_test.1342.dump.1494417300", complete)
+	writeDump("glusterdump.2311.dump.1494417600", complete)
+	writeDump("mnt-gluster-gv_old.999.dump.1494417600", complete)
+
+	reader := newStatedumpReader(dir, time.Minute)
+	reader.running = func(pid int) bool { return pid != 999 }
+	reader.triggered[1342] = time.Unix(1494417600, 500)
+	triggered := writeDump("mnt-gluster-gv_test.1342.dump.1494417600", complete)
+
+	latest, err := reader.latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 2 {
+		t.Fatalf("Expected 2 statedumps of running processes and got %v", latest)
+	}
+	for _, dump := range latest {
+		if name := dump.Name(); name != "mnt-gluster-gv_test" && name != "glusterdump" {
+			t.Errorf("Unexpected process %v", name)
+		}
+	}
+	if !exists(admin) {
+		t.Error("Expected statedump not triggered by the exporter to be kept")
+	}
+	if !reader.owned[triggered] {
+		t.Error("Expected triggered statedump to be owned")
+	}
+	if _, ok := reader.parsed[triggered]; !ok {
+		t.Error("Expected complete statedump to be cached")
+	}
+
+	// a statedump being written doesn't supersede the complete one
+	reader.triggered[1342] = time.Unix(1494417900, 0)
+	writing := writeDump("mnt-gluster-gv_test.1342.dump.1494417900", partial)
+	if latest, err = reader.latest(); err != nil {
+		t.Fatal(err)
+	}
+	for _, dump := range latest {
+		if dump.Process == "mnt-gluster-gv_test.1342" && dump.Path != triggered {
+			t.Errorf("Expected incomplete statedump to be skipped, got %v", dump.Path)
+		}
+	}
+	if !exists(triggered) {
+		t.Error("Expected latest complete statedump to be kept")
+	}
+	if _, ok := reader.parsed[writing]; ok {
+		t.Error("Expected statedump being written not to be cached")
+	}
+
+	if err := ioutil.WriteFile(writing, complete, 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := reader.latest(); err != nil {
+		t.Fatal(err)
+	}
+	if exists(triggered) {
+		t.Error("Expected superseded triggered statedump to be removed")
+	}
+	if _, ok := reader.parsed[triggered]; ok {
+		t.Error("Expected removed statedump to be evicted from the cache")
+	}
+	if !exists(admin) || !exists(writing) {
+		t.Error("Expected statedump not triggered by the exporter and latest statedump to be kept")
+	}
+}
+
+func TestStatedumpReaderLatestSameName(t *testing.T) {
+	dir, err := ioutil.TempDir("", "gluster_exporter_statedump")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	complete := []byte("DUMP-START-TIME: 2017-05-10 12:00:00.118382\nDUMP-END-TIME: 2017-05-10 12:00:00.201937\n")
+	// two fuse clients dump to the same name
+	for _, name := range []string{"glusterdump.2311.dump.1494417900", "glusterdump.2312.dump.1494417600"} {
+		if err := ioutil.WriteFile(filepath.Join(dir, name), complete, 0600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	reader := newStatedumpReader(dir, 0)
+	reader.running = func(int) bool { return true }
+	latest, err := reader.latest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(latest) != 1 || latest[0].Process != "glusterdump.2311" {
+		t.Errorf("Expected only the newest statedump of glusterdump, got %v", latest)
+	}
+}
@@ -32,22 +32,46 @@ type Volumes struct {
 
 // Volume element of "gluster volume info" command
 type Volume struct {
-	XMLName    xml.Name `xml:"volume"`
-	Name       string   `xml:"name"`
-	ID         string   `xml:"id"`
-	Status     int      `xml:"status"`
-	StatusStr  string   `xml:"statusStr"`
-	BrickCount int      `xml:"brickCount"`
-	Bricks     []Brick  `xml:"bricks"`
-	DistCount  int      `xml:"distCount"`
+	XMLName         xml.Name      `xml:"volume"`
+	Name            string        `xml:"name"`
+	ID              string        `xml:"id"`
+	Status          int           `xml:"status"`
+	StatusStr       string        `xml:"statusStr"`
+	BrickCount      int           `xml:"brickCount"`
+	Bricks          VolumeBricks  `xml:"bricks"`
+	DistCount       int           `xml:"distCount"`
+	StripeCount     int           `xml:"stripeCount"`
+	ReplicaCount    int           `xml:"replicaCount"`
+	ArbiterCount    int           `xml:"arbiterCount"`
+	DisperseCount   int           `xml:"disperseCount"`
+	RedundancyCount int           `xml:"redundancyCount"`
+	Type            int           `xml:"type"`
+	TypeStr         string        `xml:"typeStr"`
+	Options         VolumeOptions `xml:"options"`
+}
+
+// VolumeOptions element of "gluster volume info" command, only options which were set are listed
+type VolumeOptions struct {
+	Option []VolumeOption `xml:"option"`
+}
+
+// VolumeOption element of "gluster volume info" command
+type VolumeOption struct {
+	Name  string `xml:"name"`
+	Value string `xml:"value"`
+}
+
+// VolumeBricks element of "gluster volume info" command, bricks are listed in the order of their subvolumes
+type VolumeBricks struct {
+	Brick []Brick `xml:"brick"`
 }
 
 // Brick element of "gluster volume info" command
 type Brick struct {
-	UUID      string `xml:"brick>uuid"`
-	Name      string `xml:"brick>name"`
-	HostUUID  string `xml:"brick>hostUuid"`
-	IsArbiter int    `xml:"brick>isArbiter"`
+	UUID      string `xml:"uuid,attr"`
+	Name      string `xml:"name"`
+	HostUUID  string `xml:"hostUuid"`
+	IsArbiter int    `xml:"isArbiter"`
 }
 
 // VolumeListXML struct represents cliOutput element of "gluster volume list" command
@@ -233,6 +257,15 @@ func VolumeProfileGvInfoCumulativeXMLUnmarshall(cmdOutBuff io.Reader) (VolumePro
 	return vol, err
 }
 
+// BrickClient is a client connection of a brick in "gluster volume status {volume} clients", its hostname is the
+// address and port the client connected from
+type BrickClient struct {
+	Hostname   string `xml:"hostname"`
+	BytesRead  uint64 `xml:"bytesRead"`
+	BytesWrite uint64 `xml:"bytesWrite"`
+	OpVersion  int    `xml:"opVersion"`
+}
+
 // VolumeStatusXML XML type of "gluster volume status"
 type VolumeStatusXML struct {
 	XMLName   xml.Name `xml:"cliOutput"`
@@ -265,7 +298,109 @@ type VolumeStatusXML struct {
 					//InodeSize  uint64 `xml:"inodeSize"`
 					InodesTotal uint64 `xml:"inodesTotal"`
 					InodesFree  uint64 `xml:"inodesFree"`
+					// only reported by "gluster volume status {volume} clients"
+					ClientsStatus struct {
+						ClientCount int           `xml:"clientCount"`
+						Client      []BrickClient `xml:"client"`
+					} `xml:"clientsStatus"`
+					// only reported by "gluster volume status {volume} mem"
+					MemStatus struct {
+						Mallinfo struct {
+							Arena    uint64 `xml:"arena"`
+							Ordblks  uint64 `xml:"ordblks"`
+							Smblks   uint64 `xml:"smblks"`
+							Hblks    uint64 `xml:"hblks"`
+							Hblkhd   uint64 `xml:"hblkhd"`
+							Usmblks  uint64 `xml:"usmblks"`
+							Fsmblks  uint64 `xml:"fsmblks"`
+							Uordblks uint64 `xml:"uordblks"`
+							Fordblks uint64 `xml:"fordblks"`
+							Keepcost uint64 `xml:"keepcost"`
+						} `xml:"mallinfo"`
+						Mempool struct {
+							Count int `xml:"count"`
+							Pool  []struct {
+								Name      string `xml:"name"`
+								HotCount  uint64 `xml:"hotCount"`
+								ColdCount uint64 `xml:"coldCount"`
+								// gluster misspells this element
+								PaddedSizeOf uint64 `xml:"padddedSizeOf"`
+								AllocCount   uint64 `xml:"allocCount"`
+								MaxAlloc     uint64 `xml:"maxAlloc"`
+								PoolMisses   uint64 `xml:"poolMisses"`
+								MaxStdAlloc  uint64 `xml:"maxStdAlloc"`
+							} `xml:"pool"`
+						} `xml:"mempool"`
+					} `xml:"memStatus"`
+					// only reported by "gluster volume status {volume} inode"
+					InodeStatus struct {
+						Connections struct {
+							Count      int `xml:"count"`
+							Connection []struct {
+								Itable struct {
+									Active struct {
+										Count uint64 `xml:"count"`
+									} `xml:"active"`
+									LRU struct {
+										Count uint64 `xml:"count"`
+									} `xml:"lru"`
+									Purge struct {
+										Count uint64 `xml:"count"`
+									} `xml:"purge"`
+								} `xml:"itable"`
+							} `xml:"connection"`
+						} `xml:"connections"`
+					} `xml:"inodeStatus"`
+					// only reported by "gluster volume status {volume} fd"
+					FdStatus struct {
+						Count      int `xml:"count"`
+						Connection []struct {
+							ID      int `xml:"id"`
+							FdTable struct {
+								RefCount  int `xml:"refCount"`
+								MaxFds    int `xml:"maxFds"`
+								FirstFree int `xml:"firstFree"`
+								Fd        []struct {
+									Entry    int `xml:"entry"`
+									RefCount int `xml:"refCount"`
+									Flags    int `xml:"flags"`
+								} `xml:"fd"`
+							} `xml:"fdTable"`
+						} `xml:"connection"`
+					} `xml:"fdStatus"`
+					// only reported by "gluster volume status {volume} callpool"
+					CallPool struct {
+						Count     int `xml:"count"`
+						CallStack []struct {
+							UID    int    `xml:"uid"`
+							GID    int    `xml:"gid"`
+							Pid    int    `xml:"pid"`
+							Unique string `xml:"unique"`
+							Op     string `xml:"op"`
+							Type   int    `xml:"type"`
+							Count  int    `xml:"count"`
+							Frame  []struct {
+								RefCount    int    `xml:"ref_count"`
+								Translator  string `xml:"translator"`
+								Complete    int    `xml:"complete"`
+								Parent      string `xml:"parent"`
+								WindingFrom string `xml:"windingFrom"`
+								UnwindingTo string `xml:"unwindingTo"`
+							} `xml:"frame"`
+						} `xml:"callStack"`
+					} `xml:"callpool"`
 				} `xml:"node"`
+				Tasks struct {
+					Task []struct {
+						Type   string `xml:"type"`
+						ID     string `xml:"id"`
+						Params struct {
+							Brick []string `xml:"brick"`
+						} `xml:"params"`
+						Status    int    `xml:"status"`
+						StatusStr string `xml:"statusStr"`
+					} `xml:"task"`
+				} `xml:"tasks"`
 			} `xml:"volume"`
 		} `xml:"volumes"`
 	} `xml:"volStatus"`
@@ -283,6 +418,19 @@ func VolumeStatusAllDetailXMLUnmarshall(cmdOutBuff io.Reader) (VolumeStatusXML,
 	return vol, err
 }
 
+// VolumeStatusXMLUnmarshall reads bytes.buffer of "gluster volume status {volume} {clients|mem|inode|fd|callpool}"
+// and returns unmarshalled xml
+func VolumeStatusXMLUnmarshall(cmdOutBuff io.Reader) (VolumeStatusXML, error) {
+	var vol VolumeStatusXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return vol, err
+	}
+	err = xml.Unmarshal(b, &vol)
+	return vol, err
+}
+
 // QuotaLimit is a struct of VolQuota
 type QuotaLimit struct {
 	XMLName        xml.Name `xml:"limit"`
@@ -321,3 +469,297 @@ func VolumeQuotaListXMLUnmarshall(cmdOutBuff io.Reader) (VolumeQuotaXML, error)
 	err = xml.Unmarshal(b, &volQuotaXML)
 	return volQuotaXML, err
 }
+
+// QuotaObjectLimit is a struct of VolQuotaObjects
+type QuotaObjectLimit struct {
+	XMLName        xml.Name `xml:"limit"`
+	Path           string   `xml:"path"`
+	HardLimit      uint64   `xml:"hard_limit"`
+	SoftLimitValue uint64   `xml:"soft_limit_value"`
+	FileCount      uint64   `xml:"file_count"`
+	DirCount       uint64   `xml:"dir_count"`
+	Available      uint64   `xml:"available"`
+	SlExceeded     string   `xml:"sl_exceeded"`
+	HlExceeded     string   `xml:"hl_exceeded"`
+}
+
+// VolQuotaObjects is a struct of VolumeQuotaObjectsXML
+type VolQuotaObjects struct {
+	XMLName     xml.Name           `xml:"volQuota"`
+	QuotaLimits []QuotaObjectLimit `xml:"limit"`
+}
+
+// VolumeQuotaObjectsXML XML type of "gluster volume quota list-objects"
+type VolumeQuotaObjectsXML struct {
+	XMLName  xml.Name        `xml:"cliOutput"`
+	OpRet    int             `xml:"opRet"`
+	OpErrno  int             `xml:"opErrno"`
+	OpErrstr string          `xml:"opErrstr"`
+	VolQuota VolQuotaObjects `xml:"volQuota"`
+}
+
+// VolumeQuotaListObjectsXMLUnmarshall function parse "gluster volume quota list-objects" XML output
+func VolumeQuotaListObjectsXMLUnmarshall(cmdOutBuff io.Reader) (VolumeQuotaObjectsXML, error) {
+	var volQuotaXML VolumeQuotaObjectsXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return volQuotaXML, err
+	}
+	err = xml.Unmarshal(b, &volQuotaXML)
+	return volQuotaXML, err
+}
+
+// GeoRepPair is a struct of GeoRepSession and represents one worker of a geo-replication session
+type GeoRepPair struct {
+	XMLName                  xml.Name `xml:"pair"`
+	MasterNode               string   `xml:"master_node"`
+	MasterBrick              string   `xml:"master_brick"`
+	SlaveUser                string   `xml:"slave_user"`
+	Slave                    string   `xml:"slave"`
+	SlaveNode                string   `xml:"slave_node"`
+	Status                   string   `xml:"status"`
+	CrawlStatus              string   `xml:"crawl_status"`
+	Entry                    string   `xml:"entry"`
+	Data                     string   `xml:"data"`
+	Meta                     string   `xml:"meta"`
+	Failures                 string   `xml:"failures"`
+	CheckpointCompleted      string   `xml:"checkpoint_completed"`
+	MasterNodeUUID           string   `xml:"master_node_uuid"`
+	LastSynced               string   `xml:"last_synced"`
+	CheckpointTime           string   `xml:"checkpoint_time"`
+	CheckpointCompletionTime string   `xml:"checkpoint_completion_time"`
+}
+
+// GeoRepSession is a struct of GeoRepVolume
+type GeoRepSession struct {
+	XMLName      xml.Name     `xml:"session"`
+	SessionSlave string       `xml:"session_slave"`
+	Pairs        []GeoRepPair `xml:"pair"`
+}
+
+// GeoRepVolume is a struct of GeoRep
+type GeoRepVolume struct {
+	XMLName  xml.Name        `xml:"volume"`
+	Name     string          `xml:"name"`
+	Sessions []GeoRepSession `xml:"sessions>session"`
+}
+
+// GeoRep is a struct of VolumeGeoRepStatusXML
+type GeoRep struct {
+	XMLName xml.Name       `xml:"geoRep"`
+	Volumes []GeoRepVolume `xml:"volume"`
+}
+
+// VolumeGeoRepStatusXML XML type of "gluster volume geo-replication status detail"
+type VolumeGeoRepStatusXML struct {
+	XMLName  xml.Name `xml:"cliOutput"`
+	OpRet    int      `xml:"opRet"`
+	OpErrno  int      `xml:"opErrno"`
+	OpErrstr string   `xml:"opErrstr"`
+	GeoRep   GeoRep   `xml:"geoRep"`
+}
+
+// VolumeGeoRepStatusXMLUnmarshall function parse "gluster volume geo-replication status detail" XML output
+func VolumeGeoRepStatusXMLUnmarshall(cmdOutBuff io.Reader) (VolumeGeoRepStatusXML, error) {
+	var geoRepXML VolumeGeoRepStatusXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return geoRepXML, err
+	}
+	err = xml.Unmarshal(b, &geoRepXML)
+	return geoRepXML, err
+}
+
+// RebalanceNode is a struct of VolRebalance and holds the progress of a rebalance or remove-brick task on one node
+type RebalanceNode struct {
+	NodeName  string  `xml:"nodeName"`
+	ID        string  `xml:"id"`
+	Files     uint64  `xml:"files"`
+	Size      uint64  `xml:"size"`
+	Lookups   uint64  `xml:"lookups"`
+	Failures  uint64  `xml:"failures"`
+	Skipped   uint64  `xml:"skipped"`
+	Status    int     `xml:"status"`
+	StatusStr string  `xml:"statusStr"`
+	Runtime   float64 `xml:"runtime"`
+}
+
+// VolRebalance is a struct of VolumeRebalanceXML and VolumeRemoveBrickXML
+type VolRebalance struct {
+	TaskID    string          `xml:"task-id"`
+	Op        int             `xml:"op"`
+	NodeCount int             `xml:"nodeCount"`
+	Node      []RebalanceNode `xml:"node"`
+	Aggregate RebalanceNode   `xml:"aggregate"`
+}
+
+// VolumeRebalanceXML XML type of "gluster volume rebalance {volume} status"
+type VolumeRebalanceXML struct {
+	XMLName      xml.Name     `xml:"cliOutput"`
+	OpRet        int          `xml:"opRet"`
+	OpErrno      int          `xml:"opErrno"`
+	OpErrstr     string       `xml:"opErrstr"`
+	VolRebalance VolRebalance `xml:"volRebalance"`
+}
+
+// VolumeRebalanceStatusXMLUnmarshall function parse "gluster volume rebalance {volume} status" XML output
+func VolumeRebalanceStatusXMLUnmarshall(cmdOutBuff io.Reader) (VolumeRebalanceXML, error) {
+	var rebalanceXML VolumeRebalanceXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return rebalanceXML, err
+	}
+	err = xml.Unmarshal(b, &rebalanceXML)
+	return rebalanceXML, err
+}
+
+// VolumeRemoveBrickXML XML type of "gluster volume remove-brick {volume} {bricks} status"
+type VolumeRemoveBrickXML struct {
+	XMLName        xml.Name     `xml:"cliOutput"`
+	OpRet          int          `xml:"opRet"`
+	OpErrno        int          `xml:"opErrno"`
+	OpErrstr       string       `xml:"opErrstr"`
+	VolRemoveBrick VolRebalance `xml:"volRemoveBrick"`
+}
+
+// VolumeRemoveBrickStatusXMLUnmarshall function parse "gluster volume remove-brick {volume} {bricks} status" XML output
+func VolumeRemoveBrickStatusXMLUnmarshall(cmdOutBuff io.Reader) (VolumeRemoveBrickXML, error) {
+	var removeBrickXML VolumeRemoveBrickXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return removeBrickXML, err
+	}
+	err = xml.Unmarshal(b, &removeBrickXML)
+	return removeBrickXML, err
+}
+
+// SnapOriginVolume is a struct of SnapVolume and describes the volume a snapshot was taken of
+type SnapOriginVolume struct {
+	Name          string `xml:"name"`
+	SnapCount     int    `xml:"snapCount"`
+	SnapRemaining int    `xml:"snapRemaining"`
+}
+
+// SnapVolume is a struct of Snapshot
+type SnapVolume struct {
+	Name         string           `xml:"name"`
+	Status       string           `xml:"status"`
+	OriginVolume SnapOriginVolume `xml:"originVolume"`
+}
+
+// Snapshot is a struct of SnapInfo
+type Snapshot struct {
+	Name        string       `xml:"name"`
+	UUID        string       `xml:"uuid"`
+	Description string       `xml:"description"`
+	CreateTime  string       `xml:"createTime"`
+	VolCount    int          `xml:"volCount"`
+	SnapVolume  []SnapVolume `xml:"snapVolume"`
+}
+
+// SnapInfo is a struct of SnapshotInfoXML
+type SnapInfo struct {
+	Count     int        `xml:"count"`
+	Snapshots []Snapshot `xml:"snapshots>snapshot"`
+}
+
+// SnapshotInfoXML XML type of "gluster snapshot info"
+type SnapshotInfoXML struct {
+	XMLName  xml.Name `xml:"cliOutput"`
+	OpRet    int      `xml:"opRet"`
+	OpErrno  int      `xml:"opErrno"`
+	OpErrstr string   `xml:"opErrstr"`
+	SnapInfo SnapInfo `xml:"snapInfo"`
+}
+
+// SnapshotInfoXMLUnmarshall function parse "gluster snapshot info" XML output
+func SnapshotInfoXMLUnmarshall(cmdOutBuff io.Reader) (SnapshotInfoXML, error) {
+	var snapInfoXML SnapshotInfoXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return snapInfoXML, err
+	}
+	err = xml.Unmarshal(b, &snapInfoXML)
+	return snapInfoXML, err
+}
+
+// SnapSystemConfig is a struct of SnapConfig and holds the cluster wide snapshot limits
+type SnapSystemConfig struct {
+	HardLimit        int    `xml:"hardLimit"`
+	SoftLimit        string `xml:"softLimit"`
+	AutoDelete       string `xml:"autoDelete"`
+	ActivateOnCreate string `xml:"activateOnCreate"`
+}
+
+// SnapVolumeConfig is a struct of SnapConfig and holds the snapshot limits of one volume
+type SnapVolumeConfig struct {
+	Name               string `xml:"name"`
+	HardLimit          int    `xml:"hardLimit"`
+	EffectiveHardLimit int    `xml:"effectiveHardLimit"`
+	SoftLimit          int    `xml:"softLimit"`
+}
+
+// SnapConfig is a struct of SnapshotConfigXML
+type SnapConfig struct {
+	SystemConfig SnapSystemConfig   `xml:"systemConfig"`
+	VolumeConfig []SnapVolumeConfig `xml:"volumeConfig>volume"`
+}
+
+// SnapshotConfigXML XML type of "gluster snapshot config"
+type SnapshotConfigXML struct {
+	XMLName    xml.Name   `xml:"cliOutput"`
+	OpRet      int        `xml:"opRet"`
+	OpErrno    int        `xml:"opErrno"`
+	OpErrstr   string     `xml:"opErrstr"`
+	SnapConfig SnapConfig `xml:"snapConfig"`
+}
+
+// SnapshotConfigXMLUnmarshall function parse "gluster snapshot config" XML output
+func SnapshotConfigXMLUnmarshall(cmdOutBuff io.Reader) (SnapshotConfigXML, error) {
+	var snapConfigXML SnapshotConfigXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return snapConfigXML, err
+	}
+	err = xml.Unmarshal(b, &snapConfigXML)
+	return snapConfigXML, err
+}
+
+// VolGetOpt is a struct of VolGetOpts and holds one option of a volume
+type VolGetOpt struct {
+	Option string `xml:"Option"`
+	Value  string `xml:"Value"`
+}
+
+// VolGetOpts is a struct of VolumeGetXML
+type VolGetOpts struct {
+	Count int         `xml:"count"`
+	Opt   []VolGetOpt `xml:"Opt"`
+}
+
+// VolumeGetXML XML type of "gluster volume get {volume} all"
+type VolumeGetXML struct {
+	XMLName    xml.Name   `xml:"cliOutput"`
+	OpRet      int        `xml:"opRet"`
+	OpErrno    int        `xml:"opErrno"`
+	OpErrstr   string     `xml:"opErrstr"`
+	VolGetOpts VolGetOpts `xml:"volGetopts"`
+}
+
+// VolumeGetXMLUnmarshall function parse "gluster volume get {volume} all" XML output
+func VolumeGetXMLUnmarshall(cmdOutBuff io.Reader) (VolumeGetXML, error) {
+	var volGetXML VolumeGetXML
+	b, err := ioutil.ReadAll(cmdOutBuff)
+	if err != nil {
+		log.Error(err)
+		return volGetXML, err
+	}
+	err = xml.Unmarshal(b, &volGetXML)
+	return volGetXML, err
+}
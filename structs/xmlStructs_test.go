@@ -54,6 +54,42 @@ func TestInfoUnmarshall(t *testing.T) {
 	t.Log("gluster volume info test was successful.")
 }
 
+func TestInfoUnmarshallLayout(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_info_layouts.xml"
+	dat, err := ioutil.ReadFile(testXMLPath)
+	if err != nil {
+		t.Fatalf("error reading testxml in Path: %v", testXMLPath)
+	}
+
+	glusterVolumeInfo, err := VolumeInfoXMLUnmarshall(bytes.NewBuffer(dat))
+	if err != nil {
+		t.Fatal(err)
+	}
+	volumes := glusterVolumeInfo.VolInfo.Volumes.Volume
+	if len(volumes) != 3 {
+		t.Fatalf("3 volumes expected but got %v", len(volumes))
+	}
+
+	arbiter := volumes[0]
+	if arbiter.ReplicaCount != 3 || arbiter.ArbiterCount != 1 || len(arbiter.Bricks.Brick) != 6 {
+		t.Errorf("replica 3 arbiter 1 with 6 bricks expected but got %+v", arbiter)
+	}
+	brick := arbiter.Bricks.Brick[2]
+	if brick.Name != "node3.example.local:/bricks/gv_arbiter/arbiter1" || brick.IsArbiter != 1 || brick.UUID != "073c4354-5e1f-4474-95b3-c2bc2a6ae13d" {
+		t.Errorf("arbiter brick expected but got %+v", brick)
+	}
+
+	disperse := volumes[1]
+	if disperse.DisperseCount != 6 || disperse.RedundancyCount != 2 {
+		t.Errorf("disperse 6 redundancy 2 expected but got %+v", disperse)
+	}
+
+	options := volumes[2].Options.Option
+	if len(options) != 2 || options[0].Name != "cluster.min-free-disk" || options[0].Value != "20%" {
+		t.Errorf("cluster.min-free-disk of 20%% expected but got %+v", options)
+	}
+}
+
 func TestPeerStatusXMLUnmarshall(t *testing.T) {
 	testXMLPath := "../test/gluster_peer_status.xml"
 	t.Log("Test xml unmarshal for 'gluster peer status' with file: ", testXMLPath)
@@ -133,6 +169,19 @@ func TestVolumeStatusAllDetailXMLUnmarshall(t *testing.T) {
 	if volumeStatus.VolStatus.Volumes.Volume[1].VolName != "gv_test2" {
 		t.Errorf("VolName of first volume doesn't match gv_test2: %v", volumeStatus.VolStatus.Volumes.Volume[1].VolName)
 	}
+
+	tasks := volumeStatus.VolStatus.Volumes.Volume[1].Tasks.Task
+	if len(tasks) != 1 {
+		t.Fatalf("Expected 1 task for gv_test2 and len is %v", len(tasks))
+	}
+
+	if tasks[0].Type != "Remove brick" {
+		t.Errorf("Expected task type Remove brick, got %v", tasks[0].Type)
+	}
+
+	if len(tasks[0].Params.Brick) != 2 {
+		t.Errorf("Expected 2 bricks in remove brick task, got %v", len(tasks[0].Params.Brick))
+	}
 }
 
 func TestVolumeProfileGvInfoCumulativeXMLUnmarshall(t *testing.T) {
@@ -269,3 +318,356 @@ func TestVolumeQuotaListXMLUnmarshall(t *testing.T) {
 	}
 
 }
+
+func TestVolumeQuotaListObjectsXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_quota_list_objects.xml"
+	dat, err := ioutil.ReadFile(testXMLPath)
+
+	if err != nil {
+		t.Errorf("error reading testxml in Path: %v", testXMLPath)
+	}
+	volumeQuotaXML, err := VolumeQuotaListObjectsXMLUnmarshall(bytes.NewBuffer(dat))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if volumeQuotaXML.OpErrno != 0 {
+		t.Error(volumeQuotaXML.OpErrstr)
+	}
+	limits := volumeQuotaXML.VolQuota.QuotaLimits
+	if len(limits) != 2 {
+		t.Fatalf("Expected %v limits and len is %v", 2, len(limits))
+	}
+
+	limit := limits[1]
+	if limit.Path != "/bar" {
+		t.Errorf("Expected path %v, got %v", "/bar", limit.Path)
+	}
+	if limit.HardLimit != 1000 || limit.SoftLimitValue != 800 {
+		t.Errorf("Expected limits 1000 and 800, got %v and %v", limit.HardLimit, limit.SoftLimitValue)
+	}
+	if limit.FileCount != 812 || limit.DirCount != 31 || limit.Available != 157 {
+		t.Errorf("Expected 812 files, 31 dirs and 157 available, got %v, %v and %v", limit.FileCount, limit.DirCount, limit.Available)
+	}
+	if limit.SlExceeded != "Yes" || limit.HlExceeded != "No" {
+		t.Errorf("Expected soft limit exceeded, got %v and %v", limit.SlExceeded, limit.HlExceeded)
+	}
+}
+
+func TestVolumeGeoRepStatusXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_geo_replication_status_detail.xml"
+	dat, err := ioutil.ReadFile(testXMLPath)
+
+	if err != nil {
+		t.Errorf("error reading testxml in Path: %v", testXMLPath)
+	}
+	geoRepXML, err := VolumeGeoRepStatusXMLUnmarshall(bytes.NewBuffer(dat))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if geoRepXML.OpErrno != 0 {
+		t.Error(geoRepXML.OpErrstr)
+	}
+
+	if len(geoRepXML.GeoRep.Volumes) != 1 {
+		t.Fatalf("Expected 1 volume and len is %v", len(geoRepXML.GeoRep.Volumes))
+	}
+
+	volume := geoRepXML.GeoRep.Volumes[0]
+	if volume.Name != "gv_test" {
+		t.Errorf("Expected volume name gv_test, got %v", volume.Name)
+	}
+
+	if len(volume.Sessions) != 1 {
+		t.Fatalf("Expected 1 session and len is %v", len(volume.Sessions))
+	}
+
+	pairs := volume.Sessions[0].Pairs
+	if len(pairs) != 3 {
+		t.Fatalf("Expected 3 pairs and len is %v", len(pairs))
+	}
+
+	expStatus := []string{"Active", "Passive", "Faulty"}
+	for i, pair := range pairs {
+		if pair.Status != expStatus[i] {
+			t.Errorf("Expected status %v for %v, got %v", expStatus[i], pair.MasterNode, pair.Status)
+		}
+	}
+
+	if pairs[0].Data != "12" {
+		t.Errorf("Expected 12 pending data operations, got %v", pairs[0].Data)
+	}
+
+	if pairs[0].LastSynced != "2017-05-10 12:34:56" {
+		t.Errorf("Expected last synced 2017-05-10 12:34:56, got %v", pairs[0].LastSynced)
+	}
+}
+
+func TestVolumeRebalanceStatusXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_rebalance_status.xml"
+	rebalanceXML, err := VolumeRebalanceStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rebalanceXML.OpErrno != 0 {
+		t.Error(rebalanceXML.OpErrstr)
+	}
+
+	nodes := rebalanceXML.VolRebalance.Node
+	if len(nodes) != 2 {
+		t.Fatalf("Expected 2 nodes and len is %v", len(nodes))
+	}
+
+	if nodes[0].Files != 1244 {
+		t.Errorf("Expected 1244 rebalanced files, got %v", nodes[0].Files)
+	}
+
+	if nodes[1].Failures != 2 || nodes[1].StatusStr != "failed" {
+		t.Errorf("Expected failed node with 2 failures, got %v with %v failures", nodes[1].StatusStr, nodes[1].Failures)
+	}
+
+	if nodes[1].Runtime != 398.5 {
+		t.Errorf("Expected runtime of 398.5, got %v", nodes[1].Runtime)
+	}
+
+	if rebalanceXML.VolRebalance.Aggregate.Lookups != 16215 {
+		t.Errorf("Expected 16215 scanned files in aggregate, got %v", rebalanceXML.VolRebalance.Aggregate.Lookups)
+	}
+}
+
+func TestVolumeRemoveBrickStatusXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_remove_brick_status.xml"
+	removeBrickXML, err := VolumeRemoveBrickStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if removeBrickXML.OpErrno != 0 {
+		t.Error(removeBrickXML.OpErrstr)
+	}
+
+	nodes := removeBrickXML.VolRemoveBrick.Node
+	if len(nodes) != 2 {
+		t.Fatalf("Expected 2 nodes and len is %v", len(nodes))
+	}
+
+	if nodes[1].Size != 943718400 {
+		t.Errorf("Expected 943718400 bytes moved, got %v", nodes[1].Size)
+	}
+
+	if nodes[1].Skipped != 1 {
+		t.Errorf("Expected 1 skipped file, got %v", nodes[1].Skipped)
+	}
+}
+
+func TestSnapshotInfoXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_snapshot_info.xml"
+	snapInfoXML, err := SnapshotInfoXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapInfoXML.OpErrno != 0 {
+		t.Error(snapInfoXML.OpErrstr)
+	}
+
+	if snapInfoXML.SnapInfo.Count != 3 || len(snapInfoXML.SnapInfo.Snapshots) != 3 {
+		t.Fatalf("Expected 3 snapshots, got count %v and len %v", snapInfoXML.SnapInfo.Count, len(snapInfoXML.SnapInfo.Snapshots))
+	}
+
+	snap := snapInfoXML.SnapInfo.Snapshots[0]
+	if snap.Name != "daily_GMT-2017.05.09-00.00.01" {
+		t.Errorf("Unexpected snapshot name %v", snap.Name)
+	}
+
+	if snap.CreateTime != "2017-05-09 00:00:01" {
+		t.Errorf("Unexpected create time %v", snap.CreateTime)
+	}
+
+	if len(snap.SnapVolume) != 1 {
+		t.Fatalf("Expected 1 snap volume and len is %v", len(snap.SnapVolume))
+	}
+
+	if snap.SnapVolume[0].Status != "Started" {
+		t.Errorf("Expected snapshot to be Started, got %v", snap.SnapVolume[0].Status)
+	}
+
+	if snap.SnapVolume[0].OriginVolume.Name != "gv_test" || snap.SnapVolume[0].OriginVolume.SnapRemaining != 254 {
+		t.Errorf("Unexpected origin volume %v", snap.SnapVolume[0].OriginVolume)
+	}
+}
+
+func TestSnapshotConfigXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_snapshot_config.xml"
+	snapConfigXML, err := SnapshotConfigXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if snapConfigXML.OpErrno != 0 {
+		t.Error(snapConfigXML.OpErrstr)
+	}
+
+	if snapConfigXML.SnapConfig.SystemConfig.HardLimit != 256 {
+		t.Errorf("Expected system hard limit of 256, got %v", snapConfigXML.SnapConfig.SystemConfig.HardLimit)
+	}
+
+	volumes := snapConfigXML.SnapConfig.VolumeConfig
+	if len(volumes) != 2 {
+		t.Fatalf("Expected 2 volumes and len is %v", len(volumes))
+	}
+
+	if volumes[1].Name != "gv_test2" || volumes[1].EffectiveHardLimit != 10 || volumes[1].SoftLimit != 9 {
+		t.Errorf("Unexpected volume config %v", volumes[1])
+	}
+}
+
+func TestVolumeGetXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_get_all.xml"
+	volGetXML, err := VolumeGetXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if volGetXML.OpErrno != 0 {
+		t.Error(volGetXML.OpErrstr)
+	}
+
+	opts := volGetXML.VolGetOpts.Opt
+	if volGetXML.VolGetOpts.Count != len(opts) {
+		t.Errorf("Expected %v options and len is %v", volGetXML.VolGetOpts.Count, len(opts))
+	}
+
+	found := false
+	for _, opt := range opts {
+		if opt.Option == "cluster.quorum-type" {
+			found = true
+			if opt.Value != "auto" {
+				t.Errorf("Expected cluster.quorum-type auto, got %v", opt.Value)
+			}
+		}
+	}
+	if !found {
+		t.Error("cluster.quorum-type not found in options")
+	}
+}
+
+func TestVolumeStatusClientsXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_status_clients.xml"
+	volumeStatus, err := VolumeStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if volumeStatus.OpErrno != 0 {
+		t.Error(volumeStatus.OpErrstr)
+	}
+
+	nodes := volumeStatus.VolStatus.Volumes.Volume[0].Node
+	if len(nodes) != 2 {
+		t.Fatalf("Expected 2 nodes and len is %v", len(nodes))
+	}
+
+	clientsStatus := nodes[0].ClientsStatus
+	if clientsStatus.ClientCount != 3 || len(clientsStatus.Client) != 3 {
+		t.Fatalf("Expected 3 clients, got count %v and len %v", clientsStatus.ClientCount, len(clientsStatus.Client))
+	}
+
+	client := clientsStatus.Client[2]
+	if client.Hostname != "10.0.1.20:1019" {
+		t.Errorf("Unexpected client hostname %v", client.Hostname)
+	}
+
+	if client.BytesRead != 81920 || client.BytesWrite != 1048576 || client.OpVersion != 30712 {
+		t.Errorf("Unexpected client %v", client)
+	}
+
+	if nodes[1].ClientsStatus.ClientCount != 0 {
+		t.Errorf("Expected no clients on second node, got %v", nodes[1].ClientsStatus.ClientCount)
+	}
+}
+
+func TestVolumeStatusMemXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_status_mem.xml"
+	volumeStatus, err := VolumeStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	memStatus := volumeStatus.VolStatus.Volumes.Volume[0].Node[0].MemStatus
+	if memStatus.Mallinfo.Arena != 12902400 || memStatus.Mallinfo.Uordblks != 10739472 {
+		t.Errorf("Unexpected mallinfo %v", memStatus.Mallinfo)
+	}
+
+	if memStatus.Mempool.Count != 2 || len(memStatus.Mempool.Pool) != 2 {
+		t.Fatalf("Expected 2 mempools, got count %v and len %v", memStatus.Mempool.Count, len(memStatus.Mempool.Pool))
+	}
+
+	pool := memStatus.Mempool.Pool[1]
+	if pool.Name != "gv_test-server:inode_t" || pool.HotCount != 131 || pool.PaddedSizeOf != 156 || pool.PoolMisses != 7 {
+		t.Errorf("Unexpected mempool %v", pool)
+	}
+}
+
+func TestVolumeStatusInodeXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_status_inode.xml"
+	volumeStatus, err := VolumeStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	connections := volumeStatus.VolStatus.Volumes.Volume[0].Node[0].InodeStatus.Connections
+	if connections.Count != 1 || len(connections.Connection) != 1 {
+		t.Fatalf("Expected 1 connection, got count %v and len %v", connections.Count, len(connections.Connection))
+	}
+
+	itable := connections.Connection[0].Itable
+	if itable.Active.Count != 2 || itable.LRU.Count != 3 || itable.Purge.Count != 0 {
+		t.Errorf("Unexpected inode table %v", itable)
+	}
+}
+
+func TestVolumeStatusFdXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_status_fd.xml"
+	volumeStatus, err := VolumeStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fdStatus := volumeStatus.VolStatus.Volumes.Volume[0].Node[0].FdStatus
+	if fdStatus.Count != 2 || len(fdStatus.Connection) != 2 {
+		t.Fatalf("Expected 2 connections, got count %v and len %v", fdStatus.Count, len(fdStatus.Connection))
+	}
+
+	if len(fdStatus.Connection[0].FdTable.Fd) != 2 || fdStatus.Connection[0].FdTable.MaxFds != 128 {
+		t.Errorf("Unexpected fd table %v", fdStatus.Connection[0].FdTable)
+	}
+}
+
+func TestVolumeStatusCallpoolXMLUnmarshall(t *testing.T) {
+	testXMLPath := "../test/gluster_volume_status_callpool.xml"
+	volumeStatus, err := VolumeStatusXMLUnmarshall(getCliBufferHelper(testXMLPath))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	callPool := volumeStatus.VolStatus.Volumes.Volume[0].Node[0].CallPool
+	if callPool.Count != 2 || len(callPool.CallStack) != 2 {
+		t.Fatalf("Expected 2 call stacks, got count %v and len %v", callPool.Count, len(callPool.CallStack))
+	}
+
+	callStack := callPool.CallStack[1]
+	if callStack.Unique != "18342" || callStack.Op != "FINODELK" || callStack.Count != 3 || len(callStack.Frame) != 3 {
+		t.Errorf("Unexpected call stack %v", callStack)
+	}
+
+	if callStack.Frame[2].Translator != "gv_test-posix" {
+		t.Errorf("Unexpected translator %v", callStack.Frame[2].Translator)
+	}
+
+	if len(volumeStatus.VolStatus.Volumes.Volume[0].Node[1].CallPool.CallStack) != 0 {
+		t.Error("Expected no call stacks on second node")
+	}
+}